@@ -0,0 +1,373 @@
+package pvss
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrInvalidDealing marks a dealing, sub-share or repair message that is
+// malformed, does not match the split it claims to belong to, or does not
+// deal the value the protocol requires.
+var ErrInvalidDealing = errors.New("invalid dealing")
+
+// Dealing is the public half of one holder's contribution to a refresh or
+// resharing: commitments to the polynomials the holder dealt. It is
+// broadcast to every holder taking part.
+type Dealing struct {
+	dealer    int
+	createdAt time.Time
+	meta      metadata // Commitments, under the share set being replaced
+	raw       []byte
+}
+
+// Dealer returns the share ID of the holder that made the dealing.
+func (d *Dealing) Dealer() int {
+	return d.dealer
+}
+
+// Bytes returns the encoding read by ParseDealing.
+func (d *Dealing) Bytes() []byte {
+	return append([]byte{}, d.raw...)
+}
+
+// SubShare is the private half of a dealing: the evaluation of the dealer's
+// polynomials at one recipient's share ID. It must reach only that
+// recipient.
+type SubShare struct {
+	dealer int
+	data   shareData // Values for the recipient, under the share set being replaced
+	raw    []byte
+}
+
+// Dealer returns the share ID of the holder that made the sub-share.
+func (s *SubShare) Dealer() int {
+	return s.dealer
+}
+
+// Recipient returns the share ID the sub-share is for.
+func (s *SubShare) Recipient() int {
+	return s.data.id
+}
+
+// Bytes returns the encoding read by ParseSubShare.
+func (s *SubShare) Bytes() []byte {
+	return append([]byte{}, s.raw...)
+}
+
+// deal shares constants[i] and blindingConstants[i] for every chunk i with
+// random polynomials of the given threshold, committing to them under meta's
+// share set and scheme. blindingConstants is nil under Feldman.
+func (pvss *PedersenVSS) deal(data shareData, meta metadata, threshold int, recipients []int, constants, blindingConstants []*Scalar) (*Dealing, map[int]*SubShare, error) {
+	if len(recipients) == 0 {
+		return nil, nil, errors.New("no recipients provided")
+	}
+	seen := make(map[int]bool)
+	for _, id := range recipients {
+		if id < 1 || id > maxShares {
+			return nil, nil, fmt.Errorf("invalid recipient share ID: %d", id)
+		}
+		if seen[id] {
+			return nil, nil, fmt.Errorf("duplicate recipient share ID: %d", id)
+		}
+		seen[id] = true
+	}
+
+	dealing := &Dealing{
+		dealer:    data.id,
+		createdAt: time.Unix(time.Now().Unix(), 0).UTC(),
+		meta: metadata{
+			set:         meta.set,
+			scheme:      meta.scheme,
+			threshold:   threshold,
+			chunkCount:  meta.chunkCount,
+			commitments: make([][]Element, meta.chunkCount),
+			wholeScalar: meta.wholeScalar,
+		},
+	}
+
+	subShares := make(map[int]*SubShare, len(recipients))
+	for _, id := range recipients {
+		sub := shareData{id: id, set: meta.set, sizes: data.sizes, values: make([]*Scalar, meta.chunkCount), wholeScalar: meta.wholeScalar}
+		if meta.scheme == SchemePedersen {
+			sub.blindings = make([]*Scalar, meta.chunkCount)
+		}
+		subShares[id] = &SubShare{dealer: data.id, data: sub}
+	}
+
+	for chunkIdx := 0; chunkIdx < meta.chunkCount; chunkIdx++ {
+		coefficients, err := pvss.generateRandomPolynomial(constants[chunkIdx], threshold)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate polynomial for chunk %d: %v", chunkIdx, err)
+		}
+
+		var blindings []*Scalar
+		if meta.scheme == SchemePedersen {
+			blindings, err = pvss.generateRandomPolynomial(blindingConstants[chunkIdx], threshold)
+			if err != nil {
+				return nil, nil, fmt.Errorf("failed to generate blinding polynomial for chunk %d: %v", chunkIdx, err)
+			}
+		}
+
+		commitments, err := pvss.generateCommitments(coefficients, blindings)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate commitments for chunk %d: %v", chunkIdx, err)
+		}
+		dealing.meta.commitments[chunkIdx] = commitments
+
+		for id, sub := range subShares {
+			sub.data.values[chunkIdx] = pvss.evaluatePolynomial(coefficients, id)
+			if blindings != nil {
+				sub.data.blindings[chunkIdx] = pvss.evaluatePolynomial(blindings, id)
+			}
+		}
+	}
+
+	dealing.raw = pvss.serializeDealing(dealing)
+	for _, sub := range subShares {
+		sub.raw = pvss.serializeSubShare(sub)
+	}
+
+	return dealing, subShares, nil
+}
+
+// receiveSubShares pairs each dealing with the recipient's sub-share from
+// the same dealer and checks the sub-share against the dealing's
+// commitments. The result is in the order of dealings.
+func (pvss *PedersenVSS) receiveSubShares(recipient int, dealings []*Dealing, subShares []*SubShare) ([]*SubShare, error) {
+	received := make(map[int]*SubShare)
+	for _, sub := range subShares {
+		if sub.data.id != recipient {
+			return nil, fmt.Errorf("%w: sub-share from holder %d is for share %d, not %d", ErrInvalidDealing, sub.dealer, sub.data.id, recipient)
+		}
+		if received[sub.dealer] != nil {
+			return nil, fmt.Errorf("%w: several sub-shares from holder %d", ErrInvalidDealing, sub.dealer)
+		}
+		received[sub.dealer] = sub
+	}
+	if len(received) != len(dealings) {
+		return nil, fmt.Errorf("%w: got %d sub-shares for %d dealings", ErrInvalidDealing, len(received), len(dealings))
+	}
+
+	paired := make([]*SubShare, len(dealings))
+	for i, dealing := range dealings {
+		sub := received[dealing.dealer]
+		if sub == nil {
+			return nil, fmt.Errorf("%w: no sub-share from holder %d", ErrInvalidDealing, dealing.dealer)
+		}
+		// Consume the sub-share so a repeated dealing is caught
+		delete(received, dealing.dealer)
+
+		valid, err := pvss.verifyShareData(sub.data, dealing.meta)
+		if err != nil {
+			return nil, fmt.Errorf("%w: sub-share from holder %d: %v", ErrInvalidDealing, dealing.dealer, err)
+		}
+		if !valid {
+			return nil, fmt.Errorf("%w: sub-share from holder %d", ErrShareMismatch, dealing.dealer)
+		}
+
+		paired[i] = sub
+	}
+
+	return paired, nil
+}
+
+// checkDealingLayout confirms a dealing belongs to the split described by
+// meta and deals polynomials of the given threshold.
+func (pvss *PedersenVSS) checkDealingLayout(dealing *Dealing, meta metadata, threshold int) error {
+	if dealing.meta.set != meta.set {
+		return fmt.Errorf("%w: dealing from holder %d is for share set %s, not %s", ErrInvalidDealing, dealing.dealer, dealing.meta.set, meta.set)
+	}
+	if dealing.meta.scheme != meta.scheme || dealing.meta.threshold != threshold || dealing.meta.chunkCount != meta.chunkCount || dealing.meta.wholeScalar != meta.wholeScalar {
+		return fmt.Errorf("%w: dealing from holder %d does not match the split layout", ErrInvalidDealing, dealing.dealer)
+	}
+	return nil
+}
+
+// latestDealing returns the most recent creation time among dealings, the
+// creation time of the split they produce.
+func latestDealing(dealings []*Dealing) time.Time {
+	var latest time.Time
+	for _, dealing := range dealings {
+		if dealing.createdAt.After(latest) {
+			latest = dealing.createdAt
+		}
+	}
+	return latest
+}
+
+// encodeShare writes share data and its metadata as a Share.
+func (pvss *PedersenVSS) encodeShare(data shareData, meta metadata) (Share, error) {
+	keyCheck, err := pvss.encodePhrase(pvss.serializeMetadata(meta))
+	if err != nil {
+		return Share{}, err
+	}
+	key, err := pvss.encodePhrase(pvss.serializeShareData(data))
+	if err != nil {
+		return Share{}, err
+	}
+
+	return Share{Key: key, KeyCheck: keyCheck}, nil
+}
+
+// serializeDealing writes [header][dealer][created at][threshold]
+// [chunk count][share set ID][commitments].
+func (pvss *PedersenVSS) serializeDealing(dealing *Dealing) []byte {
+	result := appendHeader(nil, dealingMagic, pvss.group.ID(), dealing.meta.scheme, scalarFlags(dealing.meta.wholeScalar))
+	result = appendCount(result, dealing.dealer)
+	result = binary.BigEndian.AppendUint32(result, uint32(dealing.createdAt.Unix()))
+	result = appendCount(result, dealing.meta.threshold)
+	result = appendCount(result, dealing.meta.chunkCount)
+	result = appendShareSetID(result, dealing.meta.set)
+
+	return append(result, pvss.serializeCommitments(dealing.meta)...)
+}
+
+// ParseDealing decodes a dealing produced by Dealing.Bytes.
+func (pvss *PedersenVSS) ParseDealing(data []byte) (*Dealing, error) {
+	header, offset, err := readHeader(data, dealingMagic, pvss.group.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	dealing := &Dealing{meta: metadata{scheme: header.scheme, wholeScalar: header.flags&flagWholeScalar != 0}}
+
+	dealing.dealer, offset, err = readCount(data, offset)
+	if err != nil {
+		return nil, errors.New("insufficient dealing data")
+	}
+	if offset+4 > len(data) {
+		return nil, errors.New("insufficient dealing data")
+	}
+	dealing.createdAt = time.Unix(int64(binary.BigEndian.Uint32(data[offset:])), 0).UTC()
+	offset += 4
+
+	dealing.meta.threshold, offset, err = readCount(data, offset)
+	if err != nil {
+		return nil, errors.New("insufficient dealing data")
+	}
+	dealing.meta.chunkCount, offset, err = readCount(data, offset)
+	if err != nil {
+		return nil, errors.New("insufficient dealing data")
+	}
+	if dealing.meta.threshold < 1 || dealing.meta.chunkCount < 1 {
+		return nil, errors.New("invalid threshold or chunk count")
+	}
+
+	dealing.meta.set, offset, err = readShareSetID(data, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	dealing.meta.commitments, err = pvss.readCommitments(data, offset, dealing.meta.threshold, dealing.meta.chunkCount)
+	if err != nil {
+		return nil, err
+	}
+
+	dealing.raw = append([]byte{}, data...)
+	return dealing, nil
+}
+
+// schemeOf returns the commitment scheme data was dealt under.
+func schemeOf(data shareData) CommitmentScheme {
+	if data.blindings == nil {
+		return SchemeFeldman
+	}
+	return SchemePedersen
+}
+
+// appendChunkValues writes [chunk sizes][values][blindings] for data. The
+// chunk count is written before them.
+func appendChunkValues(dst []byte, data shareData) []byte {
+	for _, size := range data.sizes {
+		dst = append(dst, byte(size))
+	}
+	dst = appendScalars(dst, data.values)
+
+	return appendScalars(dst, data.blindings)
+}
+
+// readChunkValues reads what appendChunkValues wrote for chunkCount chunks
+// into out, returning the offset past them. The header the message was
+// written with gives the scheme and whether chunks are whole scalars.
+func (pvss *PedersenVSS) readChunkValues(data []byte, offset, chunkCount int, header formatHeader, out *shareData) (int, error) {
+	if len(data) < offset+chunkCount {
+		return 0, errors.New("insufficient chunk length data")
+	}
+	out.wholeScalar = header.flags&flagWholeScalar != 0
+	out.sizes = make([]int, chunkCount)
+	for i := range out.sizes {
+		out.sizes[i] = int(data[offset+i])
+		if limit := pvss.maxChunkLength(out.wholeScalar); out.sizes[i] > limit {
+			return 0, fmt.Errorf("chunk %d length %d exceeds %d bytes", i, out.sizes[i], limit)
+		}
+	}
+	offset += chunkCount
+
+	var err error
+	out.values, offset, err = pvss.readScalars(data, offset, chunkCount)
+	if err != nil {
+		return 0, err
+	}
+	if header.scheme == SchemePedersen {
+		out.blindings, offset, err = pvss.readScalars(data, offset, chunkCount)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return offset, nil
+}
+
+// serializeSubShare writes [header][dealer][recipient][chunk count]
+// [share set ID][chunk sizes][values][blindings].
+func (pvss *PedersenVSS) serializeSubShare(sub *SubShare) []byte {
+	result := appendHeader(nil, subShareMagic, pvss.group.ID(), schemeOf(sub.data), scalarFlags(sub.data.wholeScalar))
+	result = appendCount(result, sub.dealer)
+	result = appendCount(result, sub.data.id)
+	result = appendCount(result, len(sub.data.values))
+	result = appendShareSetID(result, sub.data.set)
+
+	return appendChunkValues(result, sub.data)
+}
+
+// ParseSubShare decodes a sub-share produced by SubShare.Bytes.
+func (pvss *PedersenVSS) ParseSubShare(data []byte) (*SubShare, error) {
+	header, offset, err := readHeader(data, subShareMagic, pvss.group.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &SubShare{}
+
+	sub.dealer, offset, err = readCount(data, offset)
+	if err != nil {
+		return nil, errors.New("insufficient sub-share data")
+	}
+	sub.data.id, offset, err = readCount(data, offset)
+	if err != nil {
+		return nil, errors.New("insufficient sub-share data")
+	}
+	chunkCount, offset, err := readCount(data, offset)
+	if err != nil {
+		return nil, errors.New("insufficient sub-share data")
+	}
+
+	sub.data.set, offset, err = readShareSetID(data, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err = pvss.readChunkValues(data, offset, chunkCount, header, &sub.data)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset != len(data) {
+		return nil, errors.New("trailing sub-share data")
+	}
+
+	sub.raw = append([]byte{}, data...)
+	return sub, nil
+}
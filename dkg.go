@@ -0,0 +1,638 @@
+package pvss
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+)
+
+// KeyGenResult is one participant's outcome of a distributed key generation.
+type KeyGenResult struct {
+	Share     Share   // This participant's share of the joint secret
+	PublicKey Element // g^x for the joint secret x, the same for every participant
+	Qualified []int   // Participants whose dealings make up the secret, in order
+}
+
+// KeyGenOption configures GenerateSharedKey.
+type KeyGenOption func(*keyGenOptions)
+
+type keyGenOptions struct {
+	roundTimeout time.Duration
+}
+
+// WithRoundTimeout sets how long each round of a key generation waits for
+// the other participants. Participants that send nothing in time are
+// treated as silent for that round. The default is 30 seconds.
+func WithRoundTimeout(timeout time.Duration) KeyGenOption {
+	return func(opts *keyGenOptions) {
+		opts.roundTimeout = timeout
+	}
+}
+
+// dkgKind identifies the round a key generation message belongs to.
+type dkgKind byte
+
+const (
+	dkgDeal     dkgKind = iota + 1 // Broadcast: Pedersen commitments to the dealer's polynomials
+	dkgShare                       // Private: the dealer's share for the recipient
+	dkgComplain                    // Broadcast: dealers whose shares failed verification
+	dkgAnswer                      // Broadcast: a dealer's shares for its complainers
+	dkgExtract                     // Broadcast: Feldman commitments to the dealer's secret polynomial
+	dkgAccuse                      // Broadcast: shares that fail the Feldman commitments
+	dkgReveal                      // Broadcast: shares of dealers whose secret is rebuilt
+)
+
+// revealedShare is a share dealt by dealer to holder, made public.
+type revealedShare struct {
+	dealer   int
+	holder   int
+	value    *Scalar
+	blinding *Scalar
+}
+
+type dkgMessage struct {
+	kind        dkgKind
+	createdAt   time.Time       // dkgDeal
+	commitments []Element       // dkgDeal, dkgExtract
+	value       *Scalar         // dkgShare
+	blinding    *Scalar         // dkgShare
+	accused     []int           // dkgComplain
+	reveals     []revealedShare // dkgAnswer, dkgAccuse, dkgReveal
+}
+
+// keyGenRun is one participant's state during a key generation.
+type keyGenRun struct {
+	pvss      *PedersenVSS
+	transport Transport
+	id        int
+	n         int
+	threshold int
+	timeout   time.Duration
+	pending   []receivedMessage // Messages of rounds not yet reached
+}
+
+type receivedMessage struct {
+	from      int
+	broadcast bool
+	msg       *dkgMessage
+}
+
+// GenerateSharedKey runs a distributed key generation among participants
+// 1 to numParticipants, following Gennaro, Jarecki, Krawczyk and Rabin.
+// Every participant calls it with its own transport endpoint and ID. No one
+// ever holds the joint secret: each participant deals a random polynomial
+// with Pedersen commitments, and the secret is the sum of the qualified
+// dealers' constant terms.
+//
+// Shares are checked as VerifyShare would. A dealer that draws threshold or
+// more complaints, or cannot answer one with a valid share, is
+// disqualified. Qualified dealers then publish Feldman commitments to fix
+// the public key; the secret of a dealer whose Feldman commitments do not
+// match its shares is rebuilt in public from the other participants'
+// shares. The rounds assume every message arrives within the round
+// timeout, and robustness against up to threshold−1 cheating participants
+// needs numParticipants ≥ 2·threshold−1.
+//
+// The result's Share carries the joint secret as a single chunk with a
+// KeyCheck of the summed Pedersen commitments, so it can be verified,
+// refreshed, reshared and repaired like any other share.
+func (pvss *PedersenVSS) GenerateSharedKey(ctx context.Context, transport Transport, id, numParticipants, threshold int, opts ...KeyGenOption) (*KeyGenResult, error) {
+	options := keyGenOptions{roundTimeout: 30 * time.Second}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if threshold > numParticipants {
+		return nil, errors.New("threshold cannot be greater than number of participants")
+	}
+	if threshold < 1 {
+		return nil, errors.New("threshold must be at least 1")
+	}
+	if numParticipants > maxShares {
+		return nil, fmt.Errorf("number of participants cannot exceed %d", maxShares)
+	}
+	if id < 1 || id > numParticipants {
+		return nil, fmt.Errorf("invalid participant ID: %d", id)
+	}
+
+	run := &keyGenRun{
+		pvss:      pvss,
+		transport: transport,
+		id:        id,
+		n:         numParticipants,
+		threshold: threshold,
+		timeout:   options.roundTimeout,
+	}
+
+	return run.execute(ctx)
+}
+
+func (run *keyGenRun) execute(ctx context.Context) (*KeyGenResult, error) {
+	pvss := run.pvss
+	others := make([]int, 0, run.n-1)
+	for id := 1; id <= run.n; id++ {
+		if id != run.id {
+			others = append(others, id)
+		}
+	}
+
+	// Deal a random secret with Pedersen commitments
+	secret, err := pvss.randomScalar()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate secret: %v", err)
+	}
+	coefficients, err := pvss.generateRandomPolynomial(secret, run.threshold)
+	if err != nil {
+		return nil, err
+	}
+	blindingSecret, err := pvss.randomScalar()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate blinding: %v", err)
+	}
+	blindings, err := pvss.generateRandomPolynomial(blindingSecret, run.threshold)
+	if err != nil {
+		return nil, err
+	}
+	commitments, err := pvss.generateCommitments(coefficients, blindings)
+	if err != nil {
+		return nil, err
+	}
+
+	createdAt := time.Unix(time.Now().Unix(), 0).UTC()
+	if err := run.broadcast(ctx, &dkgMessage{kind: dkgDeal, createdAt: createdAt, commitments: commitments}); err != nil {
+		return nil, err
+	}
+	for _, to := range others {
+		share := &dkgMessage{
+			kind:     dkgShare,
+			value:    pvss.evaluatePolynomial(coefficients, to),
+			blinding: pvss.evaluatePolynomial(blindings, to),
+		}
+		if err := run.transport.Send(ctx, to, pvss.serializeKeyGenMessage(share)); err != nil {
+			return nil, fmt.Errorf("failed to send share to participant %d: %v", to, err)
+		}
+	}
+
+	deals, err := run.collect(ctx, dkgDeal, others)
+	if err != nil {
+		return nil, err
+	}
+	deals[run.id] = &dkgMessage{kind: dkgDeal, createdAt: createdAt, commitments: commitments}
+
+	received, err := run.collect(ctx, dkgShare, others)
+	if err != nil {
+		return nil, err
+	}
+	shares := map[int]*revealedShare{
+		run.id: {
+			dealer:   run.id,
+			holder:   run.id,
+			value:    pvss.evaluatePolynomial(coefficients, run.id),
+			blinding: pvss.evaluatePolynomial(blindings, run.id),
+		},
+	}
+
+	// Complain about every dealer whose share is missing or does not verify
+	complaint := &dkgMessage{kind: dkgComplain}
+	for _, dealer := range others {
+		if deals[dealer] == nil {
+			continue
+		}
+		msg := received[dealer]
+		if msg != nil {
+			share := &revealedShare{dealer: dealer, holder: run.id, value: msg.value, blinding: msg.blinding}
+			if run.verifyPedersen(deals[dealer], share) {
+				shares[dealer] = share
+				continue
+			}
+		}
+		complaint.accused = append(complaint.accused, dealer)
+	}
+	complaints, err := run.exchange(ctx, complaint, others)
+	if err != nil {
+		return nil, err
+	}
+
+	against := make(map[int][]int)
+	for _, from := range sortedKeys(complaints) {
+		seen := make(map[int]bool)
+		for _, dealer := range complaints[from].accused {
+			if !seen[dealer] && dealer != from {
+				seen[dealer] = true
+				against[dealer] = append(against[dealer], from)
+			}
+		}
+	}
+
+	// Answer complaints by revealing the shares in question
+	answer := &dkgMessage{kind: dkgAnswer}
+	for _, holder := range against[run.id] {
+		answer.reveals = append(answer.reveals, revealedShare{
+			dealer:   run.id,
+			holder:   holder,
+			value:    pvss.evaluatePolynomial(coefficients, holder),
+			blinding: pvss.evaluatePolynomial(blindings, holder),
+		})
+	}
+	answers, err := run.exchange(ctx, answer, others)
+	if err != nil {
+		return nil, err
+	}
+
+	var qualified []int
+	for dealer := 1; dealer <= run.n; dealer++ {
+		if deals[dealer] == nil || len(against[dealer]) >= run.threshold {
+			continue
+		}
+
+		answered := make(map[int]*revealedShare)
+		if msg := answers[dealer]; msg != nil {
+			for i := range msg.reveals {
+				reveal := &msg.reveals[i]
+				if reveal.dealer == dealer && run.verifyPedersen(deals[dealer], reveal) {
+					answered[reveal.holder] = reveal
+				}
+			}
+		}
+
+		valid := true
+		for _, holder := range against[dealer] {
+			if answered[holder] == nil {
+				valid = false
+			}
+		}
+		if !valid {
+			continue
+		}
+
+		qualified = append(qualified, dealer)
+		if shares[dealer] == nil {
+			shares[dealer] = answered[run.id]
+		}
+	}
+
+	// Qualified dealers commit to their secret polynomial alone, fixing the
+	// public key
+	feldman, err := pvss.generateCommitments(coefficients, nil)
+	if err != nil {
+		return nil, err
+	}
+	qualifiedOthers := slices.DeleteFunc(slices.Clone(qualified), func(id int) bool { return id == run.id })
+	if slices.Contains(qualified, run.id) {
+		if err := run.broadcast(ctx, &dkgMessage{kind: dkgExtract, commitments: feldman}); err != nil {
+			return nil, err
+		}
+	}
+	extracts, err := run.collect(ctx, dkgExtract, qualifiedOthers)
+	if err != nil {
+		return nil, err
+	}
+	extracts[run.id] = &dkgMessage{kind: dkgExtract, commitments: feldman}
+
+	// Accuse dealers whose Feldman commitments do not match their share,
+	// revealing the share as evidence
+	accusation := &dkgMessage{kind: dkgAccuse}
+	for _, dealer := range qualifiedOthers {
+		if !run.verifyFeldman(extracts[dealer], shares[dealer]) {
+			accusation.reveals = append(accusation.reveals, *shares[dealer])
+		}
+	}
+	accusations, err := run.exchange(ctx, accusation, others)
+	if err != nil {
+		return nil, err
+	}
+
+	var rebuild []int
+	for _, dealer := range qualified {
+		for _, from := range sortedKeys(accusations) {
+			if run.validAccusation(accusations[from], from, dealer, deals, extracts) {
+				rebuild = append(rebuild, dealer)
+				break
+			}
+		}
+	}
+
+	publicKey := pvss.group.Identity()
+	for _, dealer := range qualified {
+		if !slices.Contains(rebuild, dealer) {
+			publicKey = publicKey.Add(extracts[dealer].commitments[0])
+		}
+	}
+
+	// Rebuild the secret of every accused dealer from everyone's shares
+	if len(rebuild) > 0 {
+		reveal := &dkgMessage{kind: dkgReveal}
+		for _, dealer := range rebuild {
+			if dealer != run.id {
+				reveal.reveals = append(reveal.reveals, *shares[dealer])
+			}
+		}
+		reveals, err := run.exchange(ctx, reveal, others)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, dealer := range rebuild {
+			var ids []int
+			var values []*Scalar
+			for _, from := range sortedKeys(reveals) {
+				for i := range reveals[from].reveals {
+					share := &reveals[from].reveals[i]
+					if share.dealer == dealer && share.holder == from && run.verifyPedersen(deals[dealer], share) {
+						ids = append(ids, from)
+						values = append(values, share.value)
+						break
+					}
+				}
+			}
+			if len(ids) < run.threshold {
+				return nil, fmt.Errorf("cannot rebuild the secret of participant %d: need %d shares, got %d", dealer, run.threshold, len(ids))
+			}
+
+			secret, err := pvss.lagrangeInterpolation(values, ids)
+			if err != nil {
+				return nil, err
+			}
+			publicKey = publicKey.Add(pvss.commit(secret, nil))
+		}
+	}
+
+	// Sum the qualified dealings into this participant's share
+	data := shareData{
+		id:          run.id,
+		sizes:       []int{pvss.field.size},
+		values:      []*Scalar{pvss.field.newScalar()},
+		blindings:   []*Scalar{pvss.field.newScalar()},
+		wholeScalar: true,
+	}
+	meta := metadata{
+		scheme:      SchemePedersen,
+		threshold:   run.threshold,
+		chunkCount:  1,
+		commitments: [][]Element{make([]Element, run.threshold)},
+		wholeScalar: true,
+	}
+	for k := range meta.commitments[0] {
+		meta.commitments[0][k] = pvss.group.Identity()
+	}
+
+	var latest time.Time
+	for _, dealer := range qualified {
+		data.values[0].Add(data.values[0], shares[dealer].value)
+		data.blindings[0].Add(data.blindings[0], shares[dealer].blinding)
+		for k, commitment := range deals[dealer].commitments {
+			meta.commitments[0][k] = meta.commitments[0][k].Add(commitment)
+		}
+		if deals[dealer].createdAt.After(latest) {
+			latest = deals[dealer].createdAt
+		}
+	}
+
+	meta.set = jointShareSetID(pvss.serializeCommitments(meta), latest)
+	data.set = meta.set
+
+	share, err := pvss.encodeShare(data, meta)
+	if err != nil {
+		return nil, err
+	}
+
+	return &KeyGenResult{Share: share, PublicKey: publicKey, Qualified: qualified}, nil
+}
+
+// verifyPedersen checks a share against its dealer's Pedersen commitments.
+func (run *keyGenRun) verifyPedersen(deal *dkgMessage, share *revealedShare) bool {
+	expected := run.pvss.evaluateCommitments(deal.commitments, share.holder)
+	return expected.Equal(run.pvss.commit(share.value, share.blinding))
+}
+
+// verifyFeldman checks a share's value against its dealer's Feldman
+// commitments, which are nil if the dealer sent none.
+func (run *keyGenRun) verifyFeldman(extract *dkgMessage, share *revealedShare) bool {
+	if extract == nil {
+		return false
+	}
+	expected := run.pvss.evaluateCommitments(extract.commitments, share.holder)
+	return expected.Equal(run.pvss.commit(share.value, nil))
+}
+
+// validAccusation reports whether the participant from proves, in msg, that
+// dealer's Feldman commitments do not match the share dealer gave it.
+func (run *keyGenRun) validAccusation(msg *dkgMessage, from, dealer int, deals, extracts map[int]*dkgMessage) bool {
+	if from == dealer {
+		return false
+	}
+	for i := range msg.reveals {
+		share := &msg.reveals[i]
+		if share.dealer == dealer && share.holder == from {
+			return run.verifyPedersen(deals[dealer], share) && !run.verifyFeldman(extracts[dealer], share)
+		}
+	}
+	return false
+}
+
+func (run *keyGenRun) broadcast(ctx context.Context, msg *dkgMessage) error {
+	if err := run.transport.Broadcast(ctx, run.pvss.serializeKeyGenMessage(msg)); err != nil {
+		return fmt.Errorf("failed to broadcast: %v", err)
+	}
+	return nil
+}
+
+// exchange broadcasts msg and collects the same kind of message from every
+// participant in from. The result includes msg under this participant's ID.
+func (run *keyGenRun) exchange(ctx context.Context, msg *dkgMessage, from []int) (map[int]*dkgMessage, error) {
+	if err := run.broadcast(ctx, msg); err != nil {
+		return nil, err
+	}
+	messages, err := run.collect(ctx, msg.kind, from)
+	if err != nil {
+		return nil, err
+	}
+	messages[run.id] = msg
+	return messages, nil
+}
+
+// collect waits for one message of the given kind from each participant in
+// from, until all have arrived or the round times out. Malformed,
+// duplicate and misdirected messages are dropped; messages of later rounds
+// are kept for them.
+func (run *keyGenRun) collect(ctx context.Context, kind dkgKind, from []int) (map[int]*dkgMessage, error) {
+	expected := make(map[int]bool, len(from))
+	for _, id := range from {
+		expected[id] = true
+	}
+	collected := make(map[int]*dkgMessage, len(from))
+
+	accept := func(received receivedMessage) {
+		switch {
+		case received.msg.kind > kind:
+			run.pending = append(run.pending, received)
+		case received.msg.kind == kind && expected[received.from] && collected[received.from] == nil &&
+			received.broadcast == (kind != dkgShare):
+			collected[received.from] = received.msg
+		}
+	}
+
+	pending := run.pending
+	run.pending = nil
+	for _, received := range pending {
+		accept(received)
+	}
+
+	roundCtx, cancel := context.WithTimeout(ctx, run.timeout)
+	defer cancel()
+
+	for len(collected) < len(from) {
+		message, err := run.transport.Receive(roundCtx)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, ctx.Err()
+			}
+			if roundCtx.Err() != nil {
+				break
+			}
+			return nil, fmt.Errorf("failed to receive: %v", err)
+		}
+
+		msg, err := run.pvss.parseKeyGenMessage(message.Payload, run.threshold)
+		if err != nil {
+			continue
+		}
+		accept(receivedMessage{from: message.From, broadcast: message.Broadcast, msg: msg})
+	}
+
+	return collected, nil
+}
+
+// sortedKeys returns the participant IDs of messages in increasing order.
+func sortedKeys(messages map[int]*dkgMessage) []int {
+	ids := make([]int, 0, len(messages))
+	for id := range messages {
+		ids = append(ids, id)
+	}
+	slices.Sort(ids)
+	return ids
+}
+
+// serializeKeyGenMessage writes [header][kind] followed by the fields of
+// that kind of message.
+func (pvss *PedersenVSS) serializeKeyGenMessage(msg *dkgMessage) []byte {
+	result := appendHeader(nil, keyGenMagic, pvss.group.ID(), SchemePedersen, 0)
+	result = append(result, byte(msg.kind))
+
+	switch msg.kind {
+	case dkgDeal, dkgExtract:
+		if msg.kind == dkgDeal {
+			result = binary.BigEndian.AppendUint32(result, uint32(msg.createdAt.Unix()))
+		}
+		for _, commitment := range msg.commitments {
+			result = append(result, commitment.Bytes()...)
+		}
+	case dkgShare:
+		result = appendScalars(result, []*Scalar{msg.value, msg.blinding})
+	case dkgComplain:
+		result = appendCount(result, len(msg.accused))
+		for _, id := range msg.accused {
+			result = appendCount(result, id)
+		}
+	case dkgAnswer, dkgAccuse, dkgReveal:
+		result = appendCount(result, len(msg.reveals))
+		for _, reveal := range msg.reveals {
+			result = appendCount(result, reveal.dealer)
+			result = appendCount(result, reveal.holder)
+			result = appendScalars(result, []*Scalar{reveal.value, reveal.blinding})
+		}
+	}
+
+	return result
+}
+
+// parseKeyGenMessage decodes a message written by serializeKeyGenMessage
+// for a key generation with the given threshold.
+func (pvss *PedersenVSS) parseKeyGenMessage(data []byte, threshold int) (*dkgMessage, error) {
+	_, offset, err := readHeader(data, keyGenMagic, pvss.group.ID())
+	if err != nil {
+		return nil, err
+	}
+	if offset >= len(data) {
+		return nil, errors.New("insufficient key generation data")
+	}
+
+	msg := &dkgMessage{kind: dkgKind(data[offset])}
+	offset++
+
+	switch msg.kind {
+	case dkgDeal, dkgExtract:
+		if msg.kind == dkgDeal {
+			if offset+4 > len(data) {
+				return nil, errors.New("insufficient key generation data")
+			}
+			msg.createdAt = time.Unix(int64(binary.BigEndian.Uint32(data[offset:])), 0).UTC()
+			offset += 4
+		}
+		commitments, err := pvss.readCommitments(data, offset, threshold, 1)
+		if err != nil {
+			return nil, err
+		}
+		msg.commitments = commitments[0]
+		return msg, nil
+
+	case dkgShare:
+		var scalars []*Scalar
+		scalars, offset, err = pvss.readScalars(data, offset, 2)
+		if err != nil {
+			return nil, err
+		}
+		msg.value, msg.blinding = scalars[0], scalars[1]
+
+	case dkgComplain:
+		var count int
+		count, offset, err = readCount(data, offset)
+		if err != nil || count > len(data)-offset {
+			return nil, errors.New("insufficient key generation data")
+		}
+		msg.accused = make([]int, count)
+		for i := range msg.accused {
+			msg.accused[i], offset, err = readCount(data, offset)
+			if err != nil {
+				return nil, errors.New("insufficient key generation data")
+			}
+		}
+
+	case dkgAnswer, dkgAccuse, dkgReveal:
+		var count int
+		count, offset, err = readCount(data, offset)
+		if err != nil || count > len(data)-offset {
+			return nil, errors.New("insufficient key generation data")
+		}
+		msg.reveals = make([]revealedShare, count)
+		for i := range msg.reveals {
+			reveal := &msg.reveals[i]
+			reveal.dealer, offset, err = readCount(data, offset)
+			if err != nil {
+				return nil, errors.New("insufficient key generation data")
+			}
+			reveal.holder, offset, err = readCount(data, offset)
+			if err != nil {
+				return nil, errors.New("insufficient key generation data")
+			}
+			var scalars []*Scalar
+			scalars, offset, err = pvss.readScalars(data, offset, 2)
+			if err != nil {
+				return nil, err
+			}
+			reveal.value, reveal.blinding = scalars[0], scalars[1]
+		}
+
+	default:
+		return nil, fmt.Errorf("unknown key generation message kind %d", msg.kind)
+	}
+
+	if offset != len(data) {
+		return nil, errors.New("trailing key generation data")
+	}
+
+	return msg, nil
+}
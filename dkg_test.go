@@ -0,0 +1,232 @@
+package pvss
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+)
+
+// tamperTransport lets a test alter the messages one participant sends
+type tamperTransport struct {
+	Transport
+	pvss      *PedersenVSS
+	threshold int
+	tamper    func(to int, msg *dkgMessage) // to is 0 for broadcasts
+}
+
+func (t *tamperTransport) Broadcast(ctx context.Context, payload []byte) error {
+	return t.Transport.Broadcast(ctx, t.apply(0, payload))
+}
+
+func (t *tamperTransport) Send(ctx context.Context, to int, payload []byte) error {
+	return t.Transport.Send(ctx, to, t.apply(to, payload))
+}
+
+func (t *tamperTransport) apply(to int, payload []byte) []byte {
+	msg, err := t.pvss.parseKeyGenMessage(payload, t.threshold)
+	if err != nil {
+		panic(err)
+	}
+	t.tamper(to, msg)
+	return t.pvss.serializeKeyGenMessage(msg)
+}
+
+// runKeyGen runs a key generation among participants 1 to n, except those
+// in absent. tampers alters the messages of the given participants.
+func runKeyGen(t *testing.T, pvss *PedersenVSS, n, threshold int, absent []int, tampers map[int]func(int, *dkgMessage), opts ...KeyGenOption) map[int]*KeyGenResult {
+	t.Helper()
+
+	network := NewMemoryNetwork(n)
+	results := make(map[int]*KeyGenResult)
+	errs := make(map[int]error)
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for id := 1; id <= n; id++ {
+		if slices.Contains(absent, id) {
+			continue
+		}
+		transport := network.Transport(id)
+		if tamper := tampers[id]; tamper != nil {
+			transport = &tamperTransport{Transport: transport, pvss: pvss, threshold: threshold, tamper: tamper}
+		}
+
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			result, err := pvss.GenerateSharedKey(context.Background(), transport, id, n, threshold, opts...)
+			mu.Lock()
+			results[id], errs[id] = result, err
+			mu.Unlock()
+		}(id)
+	}
+	wg.Wait()
+
+	for id, err := range errs {
+		if err != nil {
+			t.Fatalf("participant %d: GenerateSharedKey failed: %v", id, err)
+		}
+	}
+	return results
+}
+
+// checkJointKey checks that the given participants agree on the key and
+// that their shares verify and reconstruct its secret
+func checkJointKey(t *testing.T, pvss *PedersenVSS, results map[int]*KeyGenResult, ids []int, qualified []int) {
+	t.Helper()
+
+	first := results[ids[0]]
+	var shares []Share
+	for _, id := range ids {
+		result := results[id]
+		if result.Share.KeyCheck != first.Share.KeyCheck {
+			t.Errorf("participant %d: KeyCheck differs", id)
+		}
+		if !result.PublicKey.Equal(first.PublicKey) {
+			t.Errorf("participant %d: public key differs", id)
+		}
+		if !slices.Equal(result.Qualified, qualified) {
+			t.Errorf("participant %d: expected qualified %v, got %v", id, qualified, result.Qualified)
+		}
+
+		valid, err := pvss.VerifyShare(result.Share)
+		if err != nil || !valid {
+			t.Errorf("participant %d: share failed verification: %v", id, err)
+		}
+		shares = append(shares, result.Share)
+	}
+
+	secret, err := pvss.ReconstructBytes(shares)
+	if err != nil {
+		t.Fatalf("ReconstructBytes failed: %v", err)
+	}
+	x, err := NewScalar(pvss.Group()).SetBytes(secret)
+	if err != nil {
+		t.Fatalf("reconstructed secret is not a scalar: %v", err)
+	}
+	if !pvss.Group().Generator().ScalarMult(x).Equal(first.PublicKey) {
+		t.Error("public key does not match the reconstructed secret")
+	}
+}
+
+// TestGenerateSharedKey tests a key generation in which everyone is honest
+func TestGenerateSharedKey(t *testing.T) {
+	for _, group := range []Group{P256(), Ristretto255(), Secp256k1()} {
+		t.Run(group.Name(), func(t *testing.T) {
+			pvss := NewPedersenVSS(WithGroup(group))
+
+			results := runKeyGen(t, pvss, 5, 3, nil, nil)
+			checkJointKey(t, pvss, results, []int{1, 3, 5}, []int{1, 2, 3, 4, 5})
+
+			// The shares are ordinary shares
+			shares := make([]Share, 5)
+			for id, result := range results {
+				shares[id-1] = result.Share
+			}
+			refreshed := refreshAll(t, pvss, shares, 3)
+			if same, err := pvss.VerifySameSecret(shares[0].KeyCheck, refreshed[0].KeyCheck); err != nil || !same {
+				t.Errorf("refresh of generated key: got %v, %v", same, err)
+			}
+
+			// The key is one whole scalar, and stays marked as one
+			for _, share := range []Share{shares[0], refreshed[0]} {
+				if meta, err := pvss.decodeMetadata(share.KeyCheck); err != nil || !meta.wholeScalar {
+					t.Errorf("expected whole-scalar metadata, got %v", err)
+				}
+			}
+		})
+	}
+}
+
+// TestGenerateSharedKey_Complaint tests that a complaint answered with a
+// valid share keeps the dealer qualified
+func TestGenerateSharedKey_Complaint(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	results := runKeyGen(t, pvss, 5, 3, nil, map[int]func(int, *dkgMessage){
+		2: func(to int, msg *dkgMessage) {
+			if msg.kind == dkgShare && to == 3 {
+				msg.value = NewScalar(P256()).Add(msg.value, testScalar(1))
+			}
+		},
+	})
+
+	checkJointKey(t, pvss, results, []int{1, 2, 3, 4, 5}, []int{1, 2, 3, 4, 5})
+}
+
+// TestGenerateSharedKey_Disqualified tests that a dealer drawing threshold
+// complaints is left out of the secret
+func TestGenerateSharedKey_Disqualified(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	results := runKeyGen(t, pvss, 5, 3, nil, map[int]func(int, *dkgMessage){
+		2: func(to int, msg *dkgMessage) {
+			if msg.kind == dkgShare {
+				msg.blinding = NewScalar(P256()).Add(msg.blinding, testScalar(1))
+			}
+		},
+	})
+
+	checkJointKey(t, pvss, results, []int{1, 2, 3, 4, 5}, []int{1, 3, 4, 5})
+}
+
+// TestGenerateSharedKey_BadExtract tests that a dealer publishing Feldman
+// commitments that do not match its shares has its secret rebuilt
+func TestGenerateSharedKey_BadExtract(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	results := runKeyGen(t, pvss, 5, 3, nil, map[int]func(int, *dkgMessage){
+		4: func(to int, msg *dkgMessage) {
+			if msg.kind == dkgExtract {
+				msg.commitments[1] = msg.commitments[1].Add(pvss.Group().Generator())
+			}
+		},
+	}, WithRoundTimeout(500*time.Millisecond))
+
+	// The cheater never sees its own tampering, so only the others agree
+	checkJointKey(t, pvss, results, []int{1, 2, 3, 5}, []int{1, 2, 3, 4, 5})
+}
+
+// TestGenerateSharedKey_Silent tests that a participant that never shows
+// up is left out
+func TestGenerateSharedKey_Silent(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	results := runKeyGen(t, pvss, 4, 2, []int{4}, nil, WithRoundTimeout(200*time.Millisecond))
+	checkJointKey(t, pvss, results, []int{1, 2, 3}, []int{1, 2, 3})
+}
+
+// TestGenerateSharedKey_Invalid tests parameter validation
+func TestGenerateSharedKey_Invalid(t *testing.T) {
+	pvss := NewPedersenVSS()
+	transport := NewMemoryNetwork(3).Transport(1)
+
+	for _, tc := range []struct {
+		name             string
+		id, n, threshold int
+	}{
+		{"threshold above participants", 1, 3, 4},
+		{"zero threshold", 1, 3, 0},
+		{"zero ID", 0, 3, 2},
+		{"ID above participants", 4, 3, 2},
+	} {
+		if _, err := pvss.GenerateSharedKey(context.Background(), transport, tc.id, tc.n, tc.threshold); err == nil {
+			t.Errorf("%s: expected error", tc.name)
+		}
+	}
+}
+
+// TestGenerateSharedKey_Cancel tests that cancelling the context stops a
+// participant waiting for others
+func TestGenerateSharedKey_Cancel(t *testing.T) {
+	pvss := NewPedersenVSS()
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	_, err := pvss.GenerateSharedKey(ctx, NewMemoryNetwork(3).Transport(1), 1, 3, 2)
+	if err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
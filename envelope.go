@@ -0,0 +1,136 @@
+package pvss
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"errors"
+	"fmt"
+)
+
+// An envelope is the AES-256-GCM encryption of a payload under a random
+// data key; only the key is split into shares. The ciphertext blob is
+//
+//	[magic][version][share set ID][nonce][ciphertext and tag]
+//
+// and everything before the nonce is authenticated as associated data, so
+// a blob only opens with the shares it was split with.
+const (
+	envelopeMagic   byte = 0x45 // 'E'
+	envelopeVersion byte = 1
+	envelopeKeySize      = 32
+)
+
+var (
+	// ErrInvalidEnvelope is returned for ciphertext blobs that are malformed
+	// or fail authentication.
+	ErrInvalidEnvelope = errors.New("invalid envelope")
+	// ErrEnvelopeMismatch is returned when a ciphertext blob was sealed for a
+	// different share set than the shares given to open it.
+	ErrEnvelopeMismatch = errors.New("envelope belongs to a different share set")
+)
+
+// SplitEnvelope encrypts payload with a fresh 256-bit key using AES-GCM and
+// splits only the key, so the shares stay the same size however large the
+// payload is. The returned ciphertext must be stored alongside the shares;
+// it is useless without threshold of them. Options are as for SplitBytes.
+func (pvss *PedersenVSS) SplitEnvelope(payload []byte, numShares, threshold int, opts ...SplitOption) ([]Share, []byte, error) {
+	key := make([]byte, envelopeKeySize)
+	if _, err := rand.Read(key); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate data key: %v", err)
+	}
+
+	shares, err := pvss.SplitBytes(key, numShares, threshold, opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	set, err := pvss.ShareSetID(shares[0])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	gcm, err := newEnvelopeCipher(key)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	header := appendShareSetID([]byte{envelopeMagic, envelopeVersion}, set)
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, nil, fmt.Errorf("failed to generate nonce: %v", err)
+	}
+
+	ciphertext := append(header, nonce...)
+	ciphertext = gcm.Seal(ciphertext, nonce, payload, header)
+
+	return shares, ciphertext, nil
+}
+
+// OpenEnvelope recombines the data key from shares and decrypts ciphertext
+// produced by SplitEnvelope.
+func (pvss *PedersenVSS) OpenEnvelope(shares []Share, ciphertext []byte) ([]byte, error) {
+	if len(ciphertext) < 2 || ciphertext[0] != envelopeMagic {
+		return nil, fmt.Errorf("%w: not an envelope", ErrInvalidEnvelope)
+	}
+	if ciphertext[1] != envelopeVersion {
+		return nil, fmt.Errorf("%w: envelope version %d", ErrUnsupportedVersion, ciphertext[1])
+	}
+
+	set, offset, err := readShareSetID(ciphertext, 2)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidEnvelope, err)
+	}
+	header := ciphertext[:offset]
+
+	if len(shares) == 0 {
+		return nil, errors.New("no shares provided")
+	}
+	shareSet, err := pvss.ShareSetID(shares[0])
+	if err != nil {
+		return nil, err
+	}
+	if shareSet != set {
+		return nil, fmt.Errorf("%w: sealed for %s, shares from %s", ErrEnvelopeMismatch, set, shareSet)
+	}
+
+	key, err := pvss.ReconstructBytes(shares)
+	if err != nil {
+		return nil, err
+	}
+	if len(key) != envelopeKeySize {
+		return nil, fmt.Errorf("%w: reconstructed key is %d bytes", ErrInvalidEnvelope, len(key))
+	}
+
+	gcm, err := newEnvelopeCipher(key)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(ciphertext) < offset+gcm.NonceSize()+gcm.Overhead() {
+		return nil, fmt.Errorf("%w: ciphertext too short", ErrInvalidEnvelope)
+	}
+	nonce := ciphertext[offset : offset+gcm.NonceSize()]
+
+	payload, err := gcm.Open(nil, nonce, ciphertext[offset+gcm.NonceSize():], header)
+	if err != nil {
+		return nil, fmt.Errorf("%w: authentication failed", ErrInvalidEnvelope)
+	}
+
+	return payload, nil
+}
+
+func newEnvelopeCipher(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %v", err)
+	}
+
+	gcm, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %v", err)
+	}
+
+	return gcm, nil
+}
@@ -0,0 +1,101 @@
+package pvss
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// TestEnvelope_RoundTrip tests that shares stay small for a large payload
+func TestEnvelope_RoundTrip(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	payload := make([]byte, 4096)
+	if _, err := rand.Read(payload); err != nil {
+		t.Fatalf("rand.Read failed: %v", err)
+	}
+
+	shares, ciphertext, err := pvss.SplitEnvelope(payload, 5, 3)
+	if err != nil {
+		t.Fatalf("SplitEnvelope failed: %v", err)
+	}
+
+	small, _, err := pvss.SplitEnvelope([]byte("x"), 5, 3)
+	if err != nil {
+		t.Fatalf("SplitEnvelope failed: %v", err)
+	}
+	if len(strings.Fields(shares[0].KeyCheck)) != len(strings.Fields(small[0].KeyCheck)) {
+		t.Error("expected KeyCheck size to be independent of the payload size")
+	}
+
+	for _, share := range shares {
+		if valid, err := pvss.VerifyShare(share); err != nil || !valid {
+			t.Errorf("share failed verification: %v", err)
+		}
+	}
+
+	opened, err := pvss.OpenEnvelope(shares[2:], ciphertext)
+	if err != nil {
+		t.Fatalf("OpenEnvelope failed: %v", err)
+	}
+	if !bytes.Equal(opened, payload) {
+		t.Error("opened payload does not match")
+	}
+}
+
+// TestEnvelope_Tampered tests that any change to the ciphertext is detected
+func TestEnvelope_Tampered(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	shares, ciphertext, err := pvss.SplitEnvelope([]byte("config file"), 3, 2)
+	if err != nil {
+		t.Fatalf("SplitEnvelope failed: %v", err)
+	}
+
+	for _, pos := range []int{len(ciphertext) - 1, len(ciphertext) - 20} {
+		tampered := append([]byte{}, ciphertext...)
+		tampered[pos] ^= 1
+
+		if _, err := pvss.OpenEnvelope(shares, tampered); !errors.Is(err, ErrInvalidEnvelope) {
+			t.Errorf("byte %d: expected ErrInvalidEnvelope, got %v", pos, err)
+		}
+	}
+
+	if _, err := pvss.OpenEnvelope(shares, ciphertext[:20]); !errors.Is(err, ErrInvalidEnvelope) {
+		t.Errorf("truncated: expected ErrInvalidEnvelope, got %v", err)
+	}
+}
+
+// TestEnvelope_WrongShares tests opening with shares from another split
+func TestEnvelope_WrongShares(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	_, ciphertext, err := pvss.SplitEnvelope([]byte("first"), 3, 2)
+	if err != nil {
+		t.Fatalf("SplitEnvelope failed: %v", err)
+	}
+	other, _, err := pvss.SplitEnvelope([]byte("second"), 3, 2)
+	if err != nil {
+		t.Fatalf("SplitEnvelope failed: %v", err)
+	}
+
+	if _, err := pvss.OpenEnvelope(other, ciphertext); !errors.Is(err, ErrEnvelopeMismatch) {
+		t.Errorf("expected ErrEnvelopeMismatch, got %v", err)
+	}
+}
+
+// TestEnvelope_InsufficientShares tests that fewer than threshold shares fail
+func TestEnvelope_InsufficientShares(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	shares, ciphertext, err := pvss.SplitEnvelope([]byte("payload"), 5, 3)
+	if err != nil {
+		t.Fatalf("SplitEnvelope failed: %v", err)
+	}
+
+	if _, err := pvss.OpenEnvelope(shares[:2], ciphertext); err == nil {
+		t.Error("expected error with fewer than threshold shares")
+	}
+}
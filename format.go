@@ -0,0 +1,131 @@
+package pvss
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+// Serialized shares and metadata start with a five-byte header:
+//
+//	[magic][format version][curve][commitment scheme][flags]
+//
+// The magic byte tells a Key payload from a KeyCheck payload, and both from
+// the messages exchanged during a refresh, resharing, repair or key
+// generation, and from publicly verifiable dealings and their decrypted
+// shares. Keys and KeyChecks printed before the header existed have none and
+// are still read; they are always P-256 and Feldman. Publicly verifiable
+// dealings and their decrypted shares commit with h alone and record
+// schemePublic, which no other payload may carry.
+//
+// Share IDs, thresholds and chunk counts are unsigned varints. Only format
+// version 2 is read; no released payload carries another. flagPacked marks
+// a Key or KeyCheck written with EncodingPacked, so the phrase can be
+// decoded in the mode it was written in. flagWholeScalar marks the payloads
+// of a split whose single chunk is a whole scalar, as a key generated
+// without a dealer is, rather than chunkSize bytes of a secret.
+const (
+	shareMagic    byte = 0x53 // 'S'
+	metadataMagic byte = 0x4D // 'M'
+	dealingMagic  byte = 0x52 // 'R'
+	subShareMagic byte = 0x72 // 'r'
+	maskMagic     byte = 0x70 // 'p'
+	partialMagic  byte = 0x50 // 'P'
+	keyGenMagic   byte = 0x4B // 'K'
+	publicMagic   byte = 0x56 // 'V'
+	decryptMagic  byte = 0x76 // 'v'
+
+	formatVersion byte = 2
+	headerSize         = 5
+
+	flagPacked      byte = 0x01
+	flagWholeScalar byte = 0x02
+	knownFlags           = flagPacked | flagWholeScalar
+)
+
+// Limits on a single split. Share IDs are polynomial x-coordinates and each
+// chunk carries its own polynomial, so both only need to stay well below the
+// group order; these bounds keep allocations sane when decoding.
+const (
+	maxShares = 1<<16 - 1
+	maxChunks = 1<<16 - 1
+)
+
+var (
+	// ErrUnsupportedVersion is returned for payloads written in a format
+	// version this library does not read, or using flags it does not know.
+	ErrUnsupportedVersion = errors.New("unsupported format version")
+	// ErrUnsupportedCurve is returned for payloads over a group this
+	// PedersenVSS does not use.
+	ErrUnsupportedCurve = errors.New("unsupported curve")
+)
+
+// formatHeader is the decoded payload header.
+type formatHeader struct {
+	version byte
+	curve   byte
+	scheme  CommitmentScheme
+	flags   byte
+}
+
+func appendHeader(dst []byte, magic, curve byte, scheme CommitmentScheme, flags byte) []byte {
+	return append(dst, magic, formatVersion, curve, byte(scheme), flags)
+}
+
+// scalarFlags returns the header flags of a payload from a split whose
+// chunks are whole scalars or not.
+func scalarFlags(wholeScalar bool) byte {
+	if wholeScalar {
+		return flagWholeScalar
+	}
+	return 0
+}
+
+// readHeader parses the header of a payload that starts with magic and was
+// written over the group with the given curve ID.
+func readHeader(data []byte, magic, curve byte) (formatHeader, int, error) {
+	if len(data) < headerSize || data[0] != magic {
+		return formatHeader{}, 0, errors.New("missing format header")
+	}
+
+	header := formatHeader{
+		version: data[1],
+		curve:   data[2],
+		scheme:  CommitmentScheme(data[3]),
+		flags:   data[4],
+	}
+
+	if header.version != formatVersion {
+		return formatHeader{}, 0, fmt.Errorf("%w: version %d, this library reads %d", ErrUnsupportedVersion, header.version, formatVersion)
+	}
+	if header.flags&^knownFlags != 0 {
+		return formatHeader{}, 0, fmt.Errorf("%w: unknown flags %#02x", ErrUnsupportedVersion, header.flags&^knownFlags)
+	}
+	if header.curve != curve {
+		return formatHeader{}, 0, fmt.Errorf("%w: curve ID %d", ErrUnsupportedCurve, header.curve)
+	}
+	public := magic == publicMagic || magic == decryptMagic
+	if public && header.scheme != schemePublic || !public && !header.scheme.valid() {
+		return formatHeader{}, 0, fmt.Errorf("unknown commitment scheme: %d", byte(header.scheme))
+	}
+
+	return header, headerSize, nil
+}
+
+// appendCount writes a share ID, threshold or chunk count as an unsigned
+// varint.
+func appendCount(dst []byte, n int) []byte {
+	return binary.AppendUvarint(dst, uint64(n))
+}
+
+// readCount reads a share ID, threshold or chunk count.
+func readCount(data []byte, offset int) (int, int, error) {
+	if offset >= len(data) {
+		return 0, 0, errors.New("insufficient data")
+	}
+	n, size := binary.Uvarint(data[offset:])
+	if size <= 0 || n > max(maxShares, maxChunks) {
+		return 0, 0, errors.New("invalid varint")
+	}
+	return int(n), offset + size, nil
+}
@@ -0,0 +1,299 @@
+package pvss
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// rewritePhrase decodes a phrase, applies edit to its bytes and re-encodes it
+// with a valid checksum
+func rewritePhrase(t *testing.T, pvss *PedersenVSS, phrase string, edit func([]byte) []byte) string {
+	t.Helper()
+
+	data, err := pvss.mnemonicEncoder.DecodeFromMnemonic(mustStripChecksum(t, pvss, phrase))
+	if err != nil {
+		t.Fatalf("DecodeFromMnemonic failed: %v", err)
+	}
+
+	mnemonic, err := pvss.mnemonicEncoder.EncodeToMnemonic(edit(data))
+	if err != nil {
+		t.Fatalf("EncodeToMnemonic failed: %v", err)
+	}
+	return pvss.mnemonicEncoder.AddChecksum(mnemonic)
+}
+
+// TestFormatHeader tests the header written on both payloads
+func TestFormatHeader(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	for _, scheme := range []CommitmentScheme{SchemeFeldman, SchemePedersen} {
+		shares, err := pvss.SplitBytes([]byte("headers"), 3, 2, WithCommitmentScheme(scheme))
+		if err != nil {
+			t.Fatalf("SplitBytes failed: %v", err)
+		}
+
+		keyBytes, _ := pvss.mnemonicEncoder.DecodeFromMnemonic(mustStripChecksum(t, pvss, shares[0].Key))
+		metaBytes, err := pvss.decodeMetadataBytes(shares[0].KeyCheck)
+		if err != nil {
+			t.Fatalf("decodeMetadataBytes failed: %v", err)
+		}
+
+		expectedKey := []byte{shareMagic, formatVersion, curveP256, byte(scheme), 0}
+		if !bytes.HasPrefix(keyBytes, expectedKey) {
+			t.Errorf("%v: key header %x, expected %x", scheme, keyBytes[:headerSize], expectedKey)
+		}
+
+		expectedMeta := []byte{metadataMagic, formatVersion, curveP256, byte(scheme), 0}
+		if !bytes.HasPrefix(metaBytes, expectedMeta) {
+			t.Errorf("%v: metadata header %x, expected %x", scheme, metaBytes[:headerSize], expectedMeta)
+		}
+	}
+}
+
+func mustStripChecksum(t *testing.T, pvss *PedersenVSS, phrase string) string {
+	t.Helper()
+
+	body, valid := pvss.mnemonicEncoder.VerifyChecksum(phrase)
+	if !valid {
+		t.Fatal("invalid phrase checksum")
+	}
+	return body
+}
+
+// baselineShares were printed by SplitSecret(baselineSecret, 5, 3) before
+// payloads had a format header, shares had a share set or chunk sizes, and
+// phrases had more than one checksum word.
+const baselineSecret = "baseline fixture: shares printed before format headers"
+
+const baselineKeyCheck = "gate adult veteran casino globe visual display payment husband extend cash chunk obscure unfold earn frown pelican steel hero donor lobster call pioneer thrive deputy across coffee person venue artist purity shadow green hold matter jewel runway horn clean hospital response cage laundry glass scheme grocery tip hybrid shaft admit situate element maze milk cousin cost regular text hour bird chest broccoli shoot waste prosper text twenty produce ball decrease clarify sketch tube acoustic unveil entry token thought have file north young negative disorder emerge cupboard erode staff avocado educate submit vendor live raccoon canyon awful crystal acquire february elite will help provide vibrant then install vault fence pave vivid case squirrel chimney ocean card machine half deliver write forget legal action hotel nice obtain cannon sample motor teach jeans october various knee stove around spoon lock salute buyer job bone road limit finish only rabbit"
+
+var baselineShares = []Share{
+	{Key: "cake cage confirm scan excess tuna also egg frequent filter board orange connect humble sense virus predict high ring kangaroo skate buffalo butter congress opinion can outdoor ensure spring tilt marble bottom crazy blast decorate library runway grape cattle security zebra possible donate option alley amount please clown verb february", KeyCheck: baselineKeyCheck},
+	{Key: "doctor camp thing visit claim couple odor battle ocean skin east fault hire broken dial uniform off must shift animal poverty thought flee fortune truly can cliff oppose south tray eight chest daughter weather mimic tape perfect vacuum find educate fossil twice face drama other behave insane about skate reason", KeyCheck: baselineKeyCheck},
+	{Key: "gate cage blur curtain know clinic tumble blame flight security sport easily pause danger canyon notice split pizza social mule want weasel top chaos carbon camp power vague vital awkward differ cloth bridge session attend hurt morning second one author reveal essence couple decide among chef orange panda lecture ask", KeyCheck: baselineKeyCheck},
+	{Key: "leopard can sting love dismiss such wide furnace original dismiss sausage idle soon real small rally indicate unhappy pyramid sing attack harsh dilemma crumble dynamic camp blur enlist dose frost hobby cheap team key sail damage loop swarm utility buffalo below solar that leg elbow dolphin brave animal bag useless", KeyCheck: baselineKeyCheck},
+	{Key: "park call bone term key decline ski system goat divorce arrow stem volume orange tip labor fringe capital million wheat net comic try thumb fall camera job predict remind snow sponsor bomb lesson boss option crime left desert fiscal illegal image humble fuel beyond food gaze veteran diamond light soccer", KeyCheck: baselineKeyCheck},
+}
+
+// TestFormatHeader_BaselineMetadata tests that KeyCheck metadata written
+// before the header existed is read as Feldman commitments
+func TestFormatHeader_BaselineMetadata(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	meta, err := pvss.decodeMetadata(baselineKeyCheck)
+	if err != nil {
+		t.Fatalf("decodeMetadata failed: %v", err)
+	}
+	if meta.scheme != SchemeFeldman || meta.threshold != 3 || meta.chunkCount != 2 || meta.set != (ShareSetID{}) {
+		t.Errorf("unexpected metadata: scheme %v, threshold %d, %d chunks, set %v", meta.scheme, meta.threshold, meta.chunkCount, meta.set)
+	}
+
+	if _, err := NewPedersenVSS(WithGroup(Secp256k1())).decodeMetadata(baselineKeyCheck); err == nil {
+		t.Error("expected headerless metadata to be rejected over secp256k1")
+	}
+}
+
+// TestFormatHeader_BaselineShares tests that shares printed before the
+// header existed still verify and reconstruct with the default reader, but
+// cannot be refreshed
+func TestFormatHeader_BaselineShares(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	// Their single-word legacy checksum is accepted because the payload has
+	// no header; a headered payload under it is not
+	legacy := NewMnemonicEncoder(BIP39EnglishWords(), WithChecksumVersion(ChecksumLegacy))
+	shares, err := pvss.SplitSecret("headered", 3, 2)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+	relabelled := Share{Key: legacy.AddChecksum(mustStripChecksum(t, pvss, shares[0].Key)), KeyCheck: shares[0].KeyCheck}
+	if _, err := pvss.VerifyShare(relabelled); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("expected a checksum error for a headered Key with the legacy checksum, got %v", err)
+	}
+	relabelled = Share{Key: shares[0].Key, KeyCheck: legacy.AddChecksum(mustStripChecksum(t, pvss, shares[0].KeyCheck))}
+	if _, err := pvss.VerifyShare(relabelled); err == nil || !strings.Contains(err.Error(), "checksum") {
+		t.Errorf("expected a checksum error for a headered KeyCheck with the legacy checksum, got %v", err)
+	}
+
+	for i, share := range baselineShares {
+		valid, err := pvss.VerifyShare(share)
+		if err != nil || !valid {
+			t.Errorf("baseline share %d failed verification: %v", i, err)
+		}
+	}
+
+	secret, err := pvss.ReconstructSecret(baselineShares[2:])
+	if err != nil {
+		t.Fatalf("ReconstructSecret failed: %v", err)
+	}
+	if secret != baselineSecret {
+		t.Errorf("expected %q, got %q", baselineSecret, secret)
+	}
+
+	robust, _, err := pvss.ReconstructBytesRobust(baselineShares, 3)
+	if err != nil {
+		t.Fatalf("ReconstructBytesRobust failed: %v", err)
+	}
+	if string(robust) != baselineSecret {
+		t.Errorf("robust: expected %q, got %q", baselineSecret, robust)
+	}
+
+	if _, _, err := pvss.DealRefresh(baselineShares[0], []int{1, 2, 3}); !errors.Is(err, ErrLegacyShare) {
+		t.Errorf("expected ErrLegacyShare, got %v", err)
+	}
+}
+
+// baselineShare83 is share 83 of SplitSecret("share eighty-three", 83, 2)
+// printed before the header existed; its ID is the share magic byte.
+var baselineShare83 = []Share{
+	{Key: "access dizzy cake inherit flavor stamp carpet punch chief during cheese deal hint seat dose hurry company flavor vacuum laundry ensure skill link enact angle giraffe salad", KeyCheck: baselineKeyCheck83},
+	{Key: "access gasp cake pulp crowd shrug nothing coast capital wheel glass nature crack romance runway visa loud mosquito dumb plug cave limb scene bracket broken swing blush", KeyCheck: baselineKeyCheck83},
+}
+
+const baselineKeyCheck83 = "dizzy addict divert suggest soccer wire theme gym shop drip draw fiction garment hidden toy erupt universe piece canal tuition surface figure wrestle hunt palace acquire maid hurdle strike amount original grape float service someone olympic hover round insane route you vote exclude whip gown enter obey normal accident season"
+
+// TestFormatHeader_BaselineShareID83 tests that a pre-header share whose ID
+// equals the share magic is read without a header, and that a payload that
+// parses neither way reports the header's error
+func TestFormatHeader_BaselineShareID83(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	data, err := pvss.decodeShareData(baselineShare83[1].Key)
+	if err != nil {
+		t.Fatalf("decodeShareData failed: %v", err)
+	}
+	if data.id != int(shareMagic) {
+		t.Errorf("expected ID %d, got %d", shareMagic, data.id)
+	}
+	if valid, err := pvss.VerifyShare(baselineShare83[1]); err != nil || !valid {
+		t.Errorf("share 83 failed verification: %v", err)
+	}
+
+	secret, err := pvss.ReconstructSecret(baselineShare83)
+	if err != nil {
+		t.Fatalf("ReconstructSecret failed: %v", err)
+	}
+	if secret != "share eighty-three" {
+		t.Errorf("expected %q, got %q", "share eighty-three", secret)
+	}
+
+	if _, err := pvss.deserializeShareData([]byte{shareMagic, formatVersion + 1, 1, 2, 0, 9}); !errors.Is(err, ErrUnsupportedVersion) {
+		t.Errorf("expected ErrUnsupportedVersion, got %v", err)
+	}
+}
+
+// TestFormatHeader_Rejects tests that unknown versions, curves and flags
+// are reported instead of misparsed
+func TestFormatHeader_Rejects(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	shares, err := pvss.SplitSecret("future", 3, 2)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		offset   int
+		value    byte
+		expected error
+	}{
+		{"future version", 1, formatVersion + 1, ErrUnsupportedVersion},
+		{"version 1", 1, 1, ErrUnsupportedVersion},
+		{"unknown curve", 2, 9, ErrUnsupportedCurve},
+		{"unknown flags", 4, 0x80, ErrUnsupportedVersion},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			edit := func(data []byte) []byte {
+				data[tt.offset] = tt.value
+				return data
+			}
+
+			key := Share{Key: rewritePhrase(t, pvss, shares[0].Key, edit), KeyCheck: shares[0].KeyCheck}
+			if _, err := pvss.VerifyShare(key); !errors.Is(err, tt.expected) {
+				t.Errorf("key: expected %v, got %v", tt.expected, err)
+			}
+
+			keyCheck := Share{Key: shares[0].Key, KeyCheck: rewritePhrase(t, pvss, shares[0].KeyCheck, edit)}
+			if _, err := pvss.VerifyShare(keyCheck); !errors.Is(err, tt.expected) {
+				t.Errorf("key check: expected %v, got %v", tt.expected, err)
+			}
+		})
+	}
+}
+
+// TestFormatHeader_SchemeMismatch tests that a Key whose header scheme
+// contradicts its blinding values is rejected
+func TestFormatHeader_SchemeMismatch(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	shares, err := pvss.SplitSecret("scheme", 3, 2)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+
+	key := rewritePhrase(t, pvss, shares[0].Key, func(data []byte) []byte {
+		data[3] = byte(SchemeFeldman)
+		return data
+	})
+
+	if _, err := pvss.decodeShareData(key); err == nil {
+		t.Error("expected scheme mismatch error")
+	}
+}
+
+// TestReadCount tests the varint count layout
+func TestReadCount(t *testing.T) {
+	for _, n := range []int{0, 1, 127, 128, 255, 256, 300, maxShares} {
+		data := appendCount([]byte{0xee}, n)
+
+		got, offset, err := readCount(data, 1)
+		if err != nil || got != n || offset != len(data) {
+			t.Errorf("readCount(%d) = (%d, %d, %v)", n, got, offset, err)
+		}
+	}
+
+	// Truncated and oversized varints
+	for _, data := range [][]byte{{}, {0x80}, appendCount(nil, maxShares+1)} {
+		if _, _, err := readCount(data, 0); err == nil {
+			t.Errorf("expected error for %x", data)
+		}
+	}
+}
+
+// TestFormatHeader_WholeScalarFlag tests that only shares marked as whole
+// scalars may record chunks of the full scalar width
+func TestFormatHeader_WholeScalarFlag(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	shares, err := pvss.SplitBytes(bytes.Repeat([]byte{0xab}, 40), 3, 2)
+	if err != nil {
+		t.Fatalf("SplitBytes failed: %v", err)
+	}
+
+	// The first chunk size follows the header, ID, chunk count and share set
+	sizeOffset := headerSize + 2 + shareSetIDSize
+	widened := rewritePhrase(t, pvss, shares[0].Key, func(data []byte) []byte {
+		data[sizeOffset] = byte(pvss.field.size)
+		return data
+	})
+	if _, err := pvss.decodeShareData(widened); err == nil {
+		t.Error("expected a full-width chunk to be rejected without the flag")
+	}
+
+	flagged := rewritePhrase(t, pvss, shares[0].Key, func(data []byte) []byte {
+		data[4] |= flagWholeScalar
+		data[sizeOffset] = byte(pvss.field.size)
+		return data
+	})
+	if _, err := pvss.decodeShareData(flagged); err != nil {
+		t.Errorf("decodeShareData failed: %v", err)
+	}
+	if _, err := pvss.VerifyShare(Share{Key: flagged, KeyCheck: shares[0].KeyCheck}); !errors.Is(err, ErrMetadataMismatch) {
+		t.Errorf("expected ErrMetadataMismatch, got %v", err)
+	}
+}
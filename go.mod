@@ -1,3 +1,5 @@
 module github.com/IzyPro/pvss
 
 go 1.23.6
+
+require golang.org/x/text v0.28.0
@@ -0,0 +1,99 @@
+package pvss
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+)
+
+// Group is a prime-order group in which commitments are computed. Scalars
+// are integers modulo Order, created with NewScalar; elements have a
+// fixed-size encoding in which the identity is all zeros.
+type Group interface {
+	// Name identifies the group, for example "P-256".
+	Name() string
+	// ID is the curve ID written into payload headers.
+	ID() byte
+	// Order is the prime order of the group, the modulus of the scalar field.
+	Order() *big.Int
+	// Identity returns the neutral element.
+	Identity() Element
+	// Generator returns the standard base element.
+	Generator() Element
+	// ElementSize is the length of an encoded element in bytes.
+	ElementSize() int
+	// DecodeElement parses an encoding produced by Element.Bytes and
+	// rejects anything that is not a group element.
+	DecodeElement(data []byte) (Element, error)
+	// HashToElement deterministically derives an element whose discrete
+	// logarithm nobody knows.
+	HashToElement(seed []byte) Element
+}
+
+// Element is an immutable element of a Group.
+type Element interface {
+	// Add returns the group sum of the element and other.
+	Add(other Element) Element
+	// ScalarMult returns the element multiplied by k, a scalar of the
+	// element's group.
+	ScalarMult(k *Scalar) Element
+	// Equal reports whether the element and other are the same.
+	Equal(other Element) bool
+	// Bytes returns the fixed-size encoding of the element.
+	Bytes() []byte
+}
+
+// Curve IDs written into payload headers.
+const (
+	curveP256         byte = 1
+	curveP384         byte = 2
+	curveP521         byte = 3
+	curveSecp256k1    byte = 4
+	curveRistretto255 byte = 5
+)
+
+// groupByID returns the group with the given curve ID, or nil.
+func groupByID(id byte) Group {
+	for _, g := range []Group{P256(), P384(), P521(), Secp256k1(), Ristretto255()} {
+		if g.ID() == id {
+			return g
+		}
+	}
+	return nil
+}
+
+// groupChunkSize is the number of secret bytes one chunk of a split over g
+// carries: the largest whole number of bytes that is always below the order.
+func groupChunkSize(g Group) int {
+	return (g.Order().BitLen() - 1) / 8
+}
+
+// hashToElement derives an element of g by hashing the seed with a counter
+// until candidate accepts the digest. Digests are expanded with further
+// SHA-256 blocks to size bytes and masked to bits bits.
+func hashToElement(seed []byte, size, bits int, candidate func([]byte) (Element, bool)) Element {
+	counter := make([]byte, 4)
+
+	for i := uint32(0); ; i++ {
+		binary.BigEndian.PutUint32(counter, i)
+		input := append(append([]byte{}, seed...), counter...)
+
+		digest := make([]byte, 0, size+sha256.Size)
+		for block := 0; len(digest) < size; block++ {
+			data := input
+			if block > 0 {
+				data = append(append([]byte{}, input...), byte(block))
+			}
+			sum := sha256.Sum256(data)
+			digest = append(digest, sum[:]...)
+		}
+		digest = digest[:size]
+		if excess := size*8 - bits; excess > 0 {
+			digest[0] &= 0xff >> excess
+		}
+
+		if element, ok := candidate(digest); ok {
+			return element
+		}
+	}
+}
@@ -0,0 +1,200 @@
+package pvss
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/IzyPro/pvss/internal/edwards25519"
+	"github.com/IzyPro/pvss/internal/edwards25519/field"
+)
+
+// ristretto255Group is the prime-order group built from edwards25519 as
+// specified in RFC 9496. Elements are encoded in 32 bytes and the identity
+// encodes as all zeros.
+type ristretto255Group struct {
+	order *big.Int
+}
+
+var groupRistretto255 = &ristretto255Group{
+	order: new(big.Int).Add(new(big.Int).Lsh(big.NewInt(1), 252), mustDecimal("27742317777372353535851937790883648493")),
+}
+
+// Constants from RFC 9496, Section 4.1.
+var (
+	ristrettoD              = mustFieldElement("37095705934669439343138083508754565189542113879843219016388785533085940283555")
+	ristrettoSqrtM1         = mustFieldElement("19681161376707505956807079304988542015446066515923890162744021073123829784752")
+	ristrettoInvSqrtAMinusD = mustFieldElement("54469307008909316920995813868745141605393597292927456921205312896311721017578")
+	fieldOne                = new(field.Element).One()
+)
+
+// Ristretto255 returns the ristretto255 group.
+func Ristretto255() Group { return groupRistretto255 }
+
+func mustDecimal(s string) *big.Int {
+	n, ok := new(big.Int).SetString(s, 10)
+	if !ok {
+		panic("pvss: invalid constant " + s)
+	}
+	return n
+}
+
+func mustFieldElement(s string) *field.Element {
+	e, err := new(field.Element).SetBytes(reversed(mustDecimal(s).FillBytes(make([]byte, 32))))
+	if err != nil {
+		panic("pvss: invalid constant " + s)
+	}
+	return e
+}
+
+func (g *ristretto255Group) Name() string     { return "ristretto255" }
+func (g *ristretto255Group) ID() byte         { return curveRistretto255 }
+func (g *ristretto255Group) Order() *big.Int  { return g.order }
+func (g *ristretto255Group) ElementSize() int { return 32 }
+
+func (g *ristretto255Group) Identity() Element {
+	return ristretto255Element{edwards25519.NewIdentityPoint()}
+}
+
+func (g *ristretto255Group) Generator() Element {
+	return ristretto255Element{edwards25519.NewGeneratorPoint()}
+}
+
+// scalar converts k to an edwards25519 scalar.
+func (g *ristretto255Group) scalar(k *Scalar) *edwards25519.Scalar {
+	s, err := edwards25519.NewScalar().SetCanonicalBytes(reversed(k.Bytes()))
+	if err != nil {
+		panic("pvss: ristretto255 scalar out of range")
+	}
+	return s
+}
+
+// DecodeElement implements the decoding of RFC 9496, Section 4.3.1.
+func (g *ristretto255Group) DecodeElement(data []byte) (Element, error) {
+	if len(data) != 32 {
+		return nil, errors.New("invalid commitment data length")
+	}
+
+	// s must be canonical and non-negative
+	s, err := new(field.Element).SetBytes(data)
+	if err != nil || !bytes.Equal(s.Bytes(), data) || s.IsNegative() == 1 {
+		return nil, errors.New("non-canonical element encoding")
+	}
+
+	ss := new(field.Element).Square(s)
+	u1 := new(field.Element).Subtract(fieldOne, ss)
+	u2 := new(field.Element).Add(fieldOne, ss)
+	u2Squared := new(field.Element).Square(u2)
+
+	// v = −(d·u1²) − u2²
+	v := new(field.Element).Square(u1)
+	v.Multiply(v, ristrettoD).Negate(v).Subtract(v, u2Squared)
+
+	invSqrt, wasSquare := new(field.Element).SqrtRatio(fieldOne, new(field.Element).Multiply(v, u2Squared))
+
+	denX := new(field.Element).Multiply(invSqrt, u2)
+	denY := new(field.Element).Multiply(invSqrt, denX)
+	denY.Multiply(denY, v)
+
+	x := new(field.Element).Add(s, s)
+	x.Multiply(x, denX).Absolute(x)
+	y := new(field.Element).Multiply(u1, denY)
+	t := new(field.Element).Multiply(x, y)
+
+	if wasSquare == 0 || t.IsNegative() == 1 || y.Equal(new(field.Element)) == 1 {
+		return nil, errors.New("invalid element encoding")
+	}
+
+	point, err := new(edwards25519.Point).SetExtendedCoordinates(x, y, new(field.Element).One(), t)
+	if err != nil {
+		return nil, errors.New("invalid element encoding")
+	}
+	return ristretto255Element{point}, nil
+}
+
+// HashToElement hashes to a candidate encoding until one decodes.
+func (g *ristretto255Group) HashToElement(seed []byte) Element {
+	return hashToElement(seed, 32, 255, func(digest []byte) (Element, bool) {
+		digest[len(digest)-1] &^= 1
+		element, err := g.DecodeElement(reversed(digest))
+		return element, err == nil
+	})
+}
+
+// ristretto255Element wraps a representative of a ristretto255
+// equivalence class of edwards25519 points.
+type ristretto255Element struct {
+	point *edwards25519.Point
+}
+
+func (e ristretto255Element) Add(other Element) Element {
+	o := other.(ristretto255Element)
+	return ristretto255Element{new(edwards25519.Point).Add(e.point, o.point)}
+}
+
+func (e ristretto255Element) ScalarMult(k *Scalar) Element {
+	return ristretto255Element{new(edwards25519.Point).ScalarMult(groupRistretto255.scalar(k), e.point)}
+}
+
+// Equal compares ristretto255 equivalence classes rather than Edwards
+// points, as in RFC 9496, Section 4.3.3.
+func (e ristretto255Element) Equal(other Element) bool {
+	o, ok := other.(ristretto255Element)
+	if !ok {
+		return false
+	}
+
+	x1, y1, _, _ := e.point.ExtendedCoordinates()
+	x2, y2, _, _ := o.point.ExtendedCoordinates()
+
+	var lhs, rhs field.Element
+	same := lhs.Multiply(x1, y2).Equal(rhs.Multiply(y1, x2))
+	same |= lhs.Multiply(y1, y2).Equal(rhs.Multiply(x1, x2))
+	return same == 1
+}
+
+// Bytes implements the encoding of RFC 9496, Section 4.3.2.
+func (e ristretto255Element) Bytes() []byte {
+	x0, y0, z0, t0 := e.point.ExtendedCoordinates()
+
+	u1 := new(field.Element).Add(z0, y0)
+	u1.Multiply(u1, new(field.Element).Subtract(z0, y0))
+	u2 := new(field.Element).Multiply(x0, y0)
+
+	// Ignore was_square since this is always square
+	u2Squared := new(field.Element).Square(u2)
+	invSqrt, _ := new(field.Element).SqrtRatio(fieldOne, u2Squared.Multiply(u2Squared, u1))
+
+	den1 := new(field.Element).Multiply(invSqrt, u1)
+	den2 := new(field.Element).Multiply(invSqrt, u2)
+	zInv := new(field.Element).Multiply(den1, den2)
+	zInv.Multiply(zInv, t0)
+
+	ix0 := new(field.Element).Multiply(x0, ristrettoSqrtM1)
+	iy0 := new(field.Element).Multiply(y0, ristrettoSqrtM1)
+	enchantedDenominator := new(field.Element).Multiply(den1, ristrettoInvSqrtAMinusD)
+
+	rotate := new(field.Element).Multiply(t0, zInv).IsNegative()
+
+	x := new(field.Element).Select(iy0, x0, rotate)
+	y := new(field.Element).Select(ix0, y0, rotate)
+	denInv := new(field.Element).Select(enchantedDenominator, den2, rotate)
+
+	negY := new(field.Element).Negate(y)
+	y.Select(negY, y, new(field.Element).Multiply(x, zInv).IsNegative())
+
+	s := new(field.Element).Subtract(z0, y)
+	s.Multiply(denInv, s).Absolute(s)
+
+	return s.Bytes()
+}
+
+// reversed returns a copy of data in the opposite byte order, converting
+// between little-endian encodings and big.Int.
+func reversed(data []byte) []byte {
+	result := make([]byte, len(data))
+	for i, b := range data {
+		result[len(data)-1-i] = b
+	}
+	return result
+}
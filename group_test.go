@@ -0,0 +1,240 @@
+package pvss
+
+import (
+	"bytes"
+	"crypto/elliptic"
+	"encoding/hex"
+	"errors"
+	"math/big"
+	"testing"
+)
+
+func allGroups() []Group {
+	return []Group{P256(), P384(), P521(), Secp256k1(), Ristretto255()}
+}
+
+// TestGroup_Arithmetic tests the group laws every implementation must obey
+func TestGroup_Arithmetic(t *testing.T) {
+	for _, group := range allGroups() {
+		t.Run(group.Name(), func(t *testing.T) {
+			g := group.Generator()
+			a, b := NewScalar(group).SetUint64(123456789), NewScalar(group).SetUint64(987654321)
+
+			sum := g.ScalarMult(a).Add(g.ScalarMult(b))
+			if !sum.Equal(g.ScalarMult(NewScalar(group).Add(a, b))) {
+				t.Error("a·G + b·G != (a+b)·G")
+			}
+
+			if !g.Add(g).Equal(g.ScalarMult(NewScalar(group).SetUint64(2))) {
+				t.Error("G + G != 2·G")
+			}
+
+			if !g.ScalarMult(NewScalar(group)).Equal(group.Identity()) {
+				t.Error("0·G is not the identity")
+			}
+
+			if !group.Identity().Add(g).Equal(g) {
+				t.Error("identity is not neutral")
+			}
+
+			negated := g.ScalarMult(NewScalar(group).Negate(NewScalar(group).SetUint64(1)))
+			if !g.Add(negated).Equal(group.Identity()) {
+				t.Error("G + (order−1)·G is not the identity")
+			}
+
+			if g.Equal(group.Identity()) {
+				t.Error("generator equals the identity")
+			}
+		})
+	}
+}
+
+// TestGroup_Encoding tests element encodings round-trip at the advertised
+// size and that the identity encodes as zeros
+func TestGroup_Encoding(t *testing.T) {
+	for _, group := range allGroups() {
+		t.Run(group.Name(), func(t *testing.T) {
+			identity := group.Identity().Bytes()
+			if len(identity) != group.ElementSize() || !isZero(identity) {
+				t.Errorf("identity encodes as %x", identity)
+			}
+
+			for _, k := range []uint64{0, 1, 2, 3, 42, 1 << 40} {
+				element := group.Generator().ScalarMult(NewScalar(group).SetUint64(k))
+				encoded := element.Bytes()
+				if len(encoded) != group.ElementSize() {
+					t.Fatalf("%d·G: encoding is %d bytes, expected %d", k, len(encoded), group.ElementSize())
+				}
+
+				decoded, err := group.DecodeElement(encoded)
+				if err != nil {
+					t.Fatalf("%d·G: decode failed: %v", k, err)
+				}
+				if !decoded.Equal(element) || !bytes.Equal(decoded.Bytes(), encoded) {
+					t.Errorf("%d·G: round trip mismatch", k)
+				}
+			}
+
+			if _, err := group.DecodeElement(make([]byte, group.ElementSize()+1)); err == nil {
+				t.Error("expected error for wrong length")
+			}
+
+			invalid := bytes.Repeat([]byte{0xff}, group.ElementSize())
+			if _, err := group.DecodeElement(invalid); err == nil {
+				t.Error("expected error for invalid encoding")
+			}
+		})
+	}
+}
+
+// TestGroup_KnownVectors tests multiples of the generator against published
+// encodings
+func TestGroup_KnownVectors(t *testing.T) {
+	tests := []struct {
+		group    Group
+		k        uint64
+		expected string
+	}{
+		// RFC 9496, Appendix A.1
+		{Ristretto255(), 1, "e2f2ae0a6abc4e71a884a961c500515f58e30b6aa582dd8db6a65945e08d2d76"},
+		{Ristretto255(), 2, "6a493210f7499cd17fecb510ae0cea23a110e8d5b901f8acadd3095c73a3b919"},
+		{Ristretto255(), 3, "94741f5d5d52755ece4f23f044ee27d5d1ea1e2bd196b462166b16152a9d0259"},
+		// SEC 1 compressed 2·G on secp256k1
+		{Secp256k1(), 2, "02c6047f9441ed7d6d3045406e95c07cd85c778e4b8cef3ca7abac09b95c709ee5"},
+	}
+
+	for _, tt := range tests {
+		encoded := hex.EncodeToString(tt.group.Generator().ScalarMult(NewScalar(tt.group).SetUint64(tt.k)).Bytes())
+		if encoded != tt.expected {
+			t.Errorf("%s %d·G: expected %s, got %s", tt.group.Name(), tt.k, tt.expected, encoded)
+		}
+	}
+}
+
+// TestGroup_MatchesCryptoElliptic tests the constant-time NIST groups
+// against the reference arithmetic in crypto/elliptic
+func TestGroup_MatchesCryptoElliptic(t *testing.T) {
+	tests := []struct {
+		group Group
+		curve elliptic.Curve
+	}{
+		{P256(), elliptic.P256()},
+		{P384(), elliptic.P384()},
+		{P521(), elliptic.P521()},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.group.Name(), func(t *testing.T) {
+			params := tt.curve.Params()
+			if tt.group.Order().Cmp(params.N) != 0 {
+				t.Fatal("order does not match")
+			}
+
+			for _, k := range []*big.Int{big.NewInt(1), big.NewInt(7), new(big.Int).Sub(params.N, big.NewInt(2))} {
+				x, y := tt.curve.ScalarBaseMult(k.Bytes())
+				expected := elliptic.MarshalCompressed(tt.curve, x, y)
+				if got := tt.group.Generator().ScalarMult(bigScalar(t, tt.group, k)).Bytes(); !bytes.Equal(got, expected) {
+					t.Errorf("%v·G: expected %x, got %x", k, expected, got)
+				}
+
+				hx, hy := elliptic.UnmarshalCompressed(tt.curve, NewPedersenVSS(WithGroup(tt.group)).h.Bytes())
+				if hx == nil {
+					t.Fatal("H does not decode with crypto/elliptic")
+				}
+				x, y = tt.curve.ScalarMult(hx, hy, k.Bytes())
+				expected = elliptic.MarshalCompressed(tt.curve, x, y)
+				if got := NewPedersenVSS(WithGroup(tt.group)).h.ScalarMult(bigScalar(t, tt.group, k)).Bytes(); !bytes.Equal(got, expected) {
+					t.Errorf("%v·H: expected %x, got %x", k, expected, got)
+				}
+			}
+		})
+	}
+}
+
+// TestGroup_ChunkSize tests that chunks follow each group's order
+func TestGroup_ChunkSize(t *testing.T) {
+	expected := map[string]int{
+		"P-256":        31,
+		"P-384":        47,
+		"P-521":        65,
+		"secp256k1":    31,
+		"ristretto255": 31,
+	}
+
+	for _, group := range allGroups() {
+		size := NewPedersenVSS(WithGroup(group)).chunkSize()
+		if size != expected[group.Name()] {
+			t.Errorf("%s: expected chunk size %d, got %d", group.Name(), expected[group.Name()], size)
+		}
+
+		// The largest chunk must stay below the order
+		largest := new(big.Int).SetBytes(bytes.Repeat([]byte{0xff}, size))
+		if largest.Cmp(group.Order()) >= 0 {
+			t.Errorf("%s: a full chunk exceeds the order", group.Name())
+		}
+	}
+}
+
+// TestGroup_SplitAndReconstruct tests a full split, verify and reconstruct
+// cycle over every group
+func TestGroup_SplitAndReconstruct(t *testing.T) {
+	secret := "a secret long enough to span several chunks of every group order"
+
+	for _, group := range allGroups() {
+		for _, scheme := range []CommitmentScheme{SchemePedersen, SchemeFeldman} {
+			t.Run(group.Name()+"/"+scheme.String(), func(t *testing.T) {
+				pvss := NewPedersenVSS(WithGroup(group))
+
+				shares, err := pvss.SplitSecret(secret, 4, 3, WithCommitmentScheme(scheme))
+				if err != nil {
+					t.Fatalf("SplitSecret failed: %v", err)
+				}
+
+				for i, share := range shares {
+					valid, err := pvss.VerifyShare(share)
+					if err != nil || !valid {
+						t.Fatalf("share %d failed verification: %v", i, err)
+					}
+				}
+
+				reconstructed, err := pvss.ReconstructSecret(shares[1:])
+				if err != nil {
+					t.Fatalf("ReconstructSecret failed: %v", err)
+				}
+				if reconstructed != secret {
+					t.Errorf("expected %q, got %q", secret, reconstructed)
+				}
+			})
+		}
+	}
+}
+
+// TestGroup_Mismatch tests that shares split over one group are rejected by
+// a PedersenVSS using another
+func TestGroup_Mismatch(t *testing.T) {
+	shares, err := NewPedersenVSS(WithGroup(Secp256k1())).SplitSecret("wrong group", 3, 2)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+
+	if _, err := NewPedersenVSS().VerifyShare(shares[0]); !errors.Is(err, ErrUnsupportedCurve) {
+		t.Errorf("expected ErrUnsupportedCurve, got %v", err)
+	}
+	if _, err := NewPedersenVSS(WithGroup(Ristretto255())).ReconstructSecret(shares); !errors.Is(err, ErrUnsupportedCurve) {
+		t.Errorf("expected ErrUnsupportedCurve, got %v", err)
+	}
+}
+
+// TestGroup_PedersenGenerator tests that every group derives its own H
+func TestGroup_PedersenGenerator(t *testing.T) {
+	for _, group := range allGroups() {
+		pvss := NewPedersenVSS(WithGroup(group))
+
+		if pvss.h.Equal(group.Generator()) || pvss.h.Equal(group.Identity()) {
+			t.Errorf("%s: H must differ from the base point and the identity", group.Name())
+		}
+		if !NewPedersenVSS(WithGroup(group)).h.Equal(pvss.h) {
+			t.Errorf("%s: H is not deterministic", group.Name())
+		}
+	}
+}
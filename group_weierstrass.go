@@ -0,0 +1,159 @@
+package pvss
+
+import (
+	"bytes"
+	"errors"
+	"math/big"
+
+	"github.com/IzyPro/pvss/internal/nistec"
+	"github.com/IzyPro/pvss/internal/secp256k1"
+)
+
+// weierstrassPoint is the constant-time point API shared by the nistec and
+// secp256k1 packages.
+type weierstrassPoint[P any] interface {
+	BytesCompressed() []byte
+	SetBytes([]byte) (P, error)
+	Add(P, P) P
+	ScalarMult(P, []byte) (P, error)
+	ScalarBaseMult([]byte) (P, error)
+}
+
+// weierstrassGroup is a prime-order short Weierstrass curve. Elements are
+// encoded as SEC 1 compressed points.
+type weierstrassGroup[P weierstrassPoint[P]] struct {
+	name      string
+	id        byte
+	newPoint  func() P
+	order     *big.Int
+	fieldBits int
+}
+
+var (
+	groupP256      = newWeierstrassGroup("P-256", curveP256, nistec.NewP256Point, "ffffffff00000000ffffffffffffffffbce6faada7179e84f3b9cac2fc632551", 256)
+	groupP384      = newWeierstrassGroup("P-384", curveP384, nistec.NewP384Point, "ffffffffffffffffffffffffffffffffffffffffffffffffc7634d81f4372ddf581a0db248b0a77aecec196accc52973", 384)
+	groupP521      = newWeierstrassGroup("P-521", curveP521, nistec.NewP521Point, "1fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffa51868783bf2f966b7fcc0148f709a5d03bb5c9b8899c47aebb6fb71e91386409", 521)
+	groupSecp256k1 = newWeierstrassGroup("secp256k1", curveSecp256k1, secp256k1.NewPoint, "fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 256)
+)
+
+// P256 returns the NIST P-256 group, the default.
+func P256() Group { return groupP256 }
+
+// P384 returns the NIST P-384 group.
+func P384() Group { return groupP384 }
+
+// P521 returns the NIST P-521 group.
+func P521() Group { return groupP521 }
+
+// Secp256k1 returns the secp256k1 group used by Bitcoin and Ethereum keys.
+func Secp256k1() Group { return groupSecp256k1 }
+
+func newWeierstrassGroup[P weierstrassPoint[P]](name string, id byte, newPoint func() P, order string, fieldBits int) *weierstrassGroup[P] {
+	n, ok := new(big.Int).SetString(order, 16)
+	if !ok {
+		panic("pvss: invalid group order for " + name)
+	}
+
+	return &weierstrassGroup[P]{
+		name:      name,
+		id:        id,
+		newPoint:  newPoint,
+		order:     n,
+		fieldBits: fieldBits,
+	}
+}
+
+func (g *weierstrassGroup[P]) Name() string    { return g.name }
+func (g *weierstrassGroup[P]) ID() byte        { return g.id }
+func (g *weierstrassGroup[P]) Order() *big.Int { return g.order }
+
+func (g *weierstrassGroup[P]) fieldSize() int {
+	return (g.fieldBits + 7) / 8
+}
+
+func (g *weierstrassGroup[P]) ElementSize() int {
+	return 1 + g.fieldSize()
+}
+
+func (g *weierstrassGroup[P]) Identity() Element {
+	return weierstrassElement[P]{group: g, point: g.newPoint()}
+}
+
+func (g *weierstrassGroup[P]) Generator() Element {
+	return g.scalarBaseMult(NewScalar(g).SetUint64(1))
+}
+
+func (g *weierstrassGroup[P]) scalarBaseMult(k *Scalar) Element {
+	point, err := g.newPoint().ScalarBaseMult(k.Bytes())
+	if err != nil {
+		panic("pvss: " + g.name + " scalar multiplication failed: " + err.Error())
+	}
+	return weierstrassElement[P]{group: g, point: point}
+}
+
+// DecodeElement parses a compressed point; the point arithmetic checks it
+// is on the curve. The identity is encoded as all zeros.
+func (g *weierstrassGroup[P]) DecodeElement(data []byte) (Element, error) {
+	if len(data) != g.ElementSize() {
+		return nil, errors.New("invalid commitment data length")
+	}
+
+	if isZero(data) {
+		return g.Identity(), nil
+	}
+
+	if data[0] != 0x02 && data[0] != 0x03 {
+		return nil, errors.New("invalid parity byte")
+	}
+
+	point, err := g.newPoint().SetBytes(data)
+	if err != nil {
+		return nil, errors.New("point not on curve")
+	}
+
+	return weierstrassElement[P]{group: g, point: point}, nil
+}
+
+// HashToElement hashes to an X coordinate with even Y.
+func (g *weierstrassGroup[P]) HashToElement(seed []byte) Element {
+	return hashToElement(seed, g.fieldSize(), g.fieldBits, func(digest []byte) (Element, bool) {
+		element, err := g.DecodeElement(append([]byte{0x02}, digest...))
+		return element, err == nil
+	})
+}
+
+// weierstrassElement wraps a point of the group's curve.
+type weierstrassElement[P weierstrassPoint[P]] struct {
+	group *weierstrassGroup[P]
+	point P
+}
+
+func (e weierstrassElement[P]) Add(other Element) Element {
+	o := other.(weierstrassElement[P])
+	return weierstrassElement[P]{group: e.group, point: e.group.newPoint().Add(e.point, o.point)}
+}
+
+// ScalarMult passes the fixed-width big-endian encoding of k, which is
+// what the point arithmetic expects.
+func (e weierstrassElement[P]) ScalarMult(k *Scalar) Element {
+	point, err := e.group.newPoint().ScalarMult(e.point, k.Bytes())
+	if err != nil {
+		panic("pvss: " + e.group.name + " scalar multiplication failed: " + err.Error())
+	}
+	return weierstrassElement[P]{group: e.group, point: point}
+}
+
+func (e weierstrassElement[P]) Equal(other Element) bool {
+	o, ok := other.(weierstrassElement[P])
+	return ok && o.group == e.group && bytes.Equal(e.point.BytesCompressed(), o.point.BytesCompressed())
+}
+
+// Bytes returns the compressed point: a 0x02 or 0x03 parity byte followed
+// by X. The point at infinity (a commitment to zero) is all zeros.
+func (e weierstrassElement[P]) Bytes() []byte {
+	compressed := e.point.BytesCompressed()
+	if len(compressed) == 1 {
+		return make([]byte, e.group.ElementSize())
+	}
+	return compressed
+}
@@ -0,0 +1,15 @@
+// Copyright (c) 2021 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package edwards25519 implements group logic for the twisted Edwards curve
+//
+//	-x^2 + y^2 = 1 + -(121665/121666)*x^2*y^2
+//
+// This is better known as the Edwards curve equivalent to Curve25519, and is
+// the curve used by the Ed25519 signature scheme.
+//
+// It is a copy of crypto/internal/fips140/edwards25519 from the Go standard
+// library, using only the portable Go field arithmetic, with accessors for
+// extended coordinates added for the ristretto255 encoding.
+package edwards25519
@@ -0,0 +1,16 @@
+// Copyright 2022 The Go Authors. All rights reserved.
+// Use of this source code is governed by a BSD-style
+// license that can be found in the LICENSE file.
+
+// Package nistec implements the elliptic curves from NIST SP 800-186.
+//
+// It is a copy of crypto/internal/fips140/nistec from the Go standard
+// library, trimmed to P-256, P-384 and P-521 and to the portable Go code.
+//
+// This package uses fiat-crypto and Go code for its
+// backend field arithmetic (not math/big) and exposes constant-time, heap
+// allocation-free, byte slice-based safe APIs. Group operations use modern and
+// safe complete addition formulas where possible. The point at infinity is
+// handled and encoded according to SEC 1, Version 2.0, and invalid curve points
+// can't be represented.
+package nistec
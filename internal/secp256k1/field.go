@@ -0,0 +1,239 @@
+package secp256k1
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/bits"
+)
+
+// fieldElement is an integer modulo p = 2²⁵⁶ − 2³² − 977, stored in the
+// Montgomery domain as x·2²⁵⁶ mod p in four little-endian 64-bit limbs.
+// Every operation leaves it fully reduced and runs in constant time.
+//
+// The zero value is a valid zero element.
+type fieldElement struct {
+	l [4]uint64
+}
+
+const fieldElementLen = 32
+
+// p is the field modulus.
+var p = [4]uint64{0xfffffffefffffc2f, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff}
+
+// pInv is −p⁻¹ mod 2⁶⁴.
+const pInv = 0xd838091dd2253531
+
+// rSquared is 2⁵¹² mod p, used to move values into the Montgomery domain.
+var rSquared = [4]uint64{0x000007a2000e90a1, 0x1, 0, 0}
+
+// One sets e = 1, and returns e.
+func (e *fieldElement) One() *fieldElement {
+	// 2²⁵⁶ mod p
+	e.l = [4]uint64{0x1000003d1, 0, 0, 0}
+	return e
+}
+
+// Set sets e = t, and returns e.
+func (e *fieldElement) Set(t *fieldElement) *fieldElement {
+	e.l = t.l
+	return e
+}
+
+// Equal returns 1 if e == t, and zero otherwise.
+func (e *fieldElement) Equal(t *fieldElement) int {
+	diff := (e.l[0] ^ t.l[0]) | (e.l[1] ^ t.l[1]) | (e.l[2] ^ t.l[2]) | (e.l[3] ^ t.l[3])
+	return int((^diff & (diff - 1)) >> 63)
+}
+
+// IsZero returns 1 if e == 0, and zero otherwise.
+func (e *fieldElement) IsZero() int {
+	return e.Equal(new(fieldElement))
+}
+
+// Select sets e to a if cond == 1, and to b if cond == 0.
+func (e *fieldElement) Select(a, b *fieldElement, cond int) *fieldElement {
+	mask := -uint64(cond)
+	for i := range e.l {
+		e.l[i] = (a.l[i] & mask) | (b.l[i] &^ mask)
+	}
+	return e
+}
+
+// Bytes returns the 32-byte big-endian encoding of e.
+func (e *fieldElement) Bytes() []byte {
+	var out [fieldElementLen]byte
+	return e.bytes(&out)
+}
+
+func (e *fieldElement) bytes(out *[fieldElementLen]byte) []byte {
+	var t fieldElement
+	montgomeryMul(&t.l, &e.l, &[4]uint64{1, 0, 0, 0})
+	for i, limb := range t.l {
+		binary.BigEndian.PutUint64(out[fieldElementLen-8*(i+1):], limb)
+	}
+	return out[:]
+}
+
+// SetBytes sets e = v, where v is a big-endian 32-byte encoding, and returns
+// e. If v is not 32 bytes or it encodes a value higher than p, SetBytes
+// returns nil and an error, and e is unchanged.
+func (e *fieldElement) SetBytes(v []byte) (*fieldElement, error) {
+	if len(v) != fieldElementLen {
+		return nil, errors.New("invalid field element length")
+	}
+
+	var t [4]uint64
+	for i := range t {
+		t[i] = binary.BigEndian.Uint64(v[fieldElementLen-8*(i+1):])
+	}
+
+	// Reject t ≥ p: t − p must borrow.
+	var borrow uint64
+	for i := range t {
+		_, borrow = bits.Sub64(t[i], p[i], borrow)
+	}
+	if borrow == 0 {
+		return nil, errors.New("invalid field element encoding")
+	}
+
+	montgomeryMul(&e.l, &t, &rSquared)
+	return e, nil
+}
+
+// Add sets e = t1 + t2, and returns e.
+func (e *fieldElement) Add(t1, t2 *fieldElement) *fieldElement {
+	var sum [4]uint64
+	var carry uint64
+	for i := range sum {
+		sum[i], carry = bits.Add64(t1.l[i], t2.l[i], carry)
+	}
+	reduceOnce(&e.l, &sum, carry)
+	return e
+}
+
+// Sub sets e = t1 - t2, and returns e.
+func (e *fieldElement) Sub(t1, t2 *fieldElement) *fieldElement {
+	var diff [4]uint64
+	var borrow uint64
+	for i := range diff {
+		diff[i], borrow = bits.Sub64(t1.l[i], t2.l[i], borrow)
+	}
+
+	// Add p back if the subtraction wrapped around.
+	mask := -borrow
+	var carry uint64
+	for i := range diff {
+		e.l[i], carry = bits.Add64(diff[i], p[i]&mask, carry)
+	}
+	return e
+}
+
+// Mul sets e = t1 * t2, and returns e.
+func (e *fieldElement) Mul(t1, t2 *fieldElement) *fieldElement {
+	montgomeryMul(&e.l, &t1.l, &t2.l)
+	return e
+}
+
+// Square sets e = t * t, and returns e.
+func (e *fieldElement) Square(t *fieldElement) *fieldElement {
+	montgomeryMul(&e.l, &t.l, &t.l)
+	return e
+}
+
+// exp sets e = x^k for a public, fixed exponent k given as little-endian
+// limbs, and returns e.
+func (e *fieldElement) exp(x *fieldElement, k [4]uint64) *fieldElement {
+	var result fieldElement
+	result.One()
+	base := *x
+
+	for i := 3; i >= 0; i-- {
+		for bit := 63; bit >= 0; bit-- {
+			result.Square(&result)
+			if k[i]>>bit&1 == 1 {
+				result.Mul(&result, &base)
+			}
+		}
+	}
+
+	return e.Set(&result)
+}
+
+// Invert sets e = 1/x, and returns e. If x == 0, Invert returns e = 0.
+func (e *fieldElement) Invert(x *fieldElement) *fieldElement {
+	// Fermat's little theorem: x^(p−2)
+	return e.exp(x, [4]uint64{0xfffffffefffffc2d, 0xffffffffffffffff, 0xffffffffffffffff, 0xffffffffffffffff})
+}
+
+// Sqrt sets e to a square root of x and returns 1 if x is a square.
+// Otherwise it returns 0 and leaves e unchanged.
+func (e *fieldElement) Sqrt(x *fieldElement) int {
+	// Since p = 3 mod 4, x^((p+1)/4) is a root if one exists.
+	candidate := new(fieldElement).exp(x, [4]uint64{0xffffffffbfffff0c, 0xffffffffffffffff, 0xffffffffffffffff, 0x3fffffffffffffff})
+
+	isSquare := new(fieldElement).Square(candidate).Equal(x)
+	e.Select(candidate, e, isSquare)
+	return isSquare
+}
+
+// IsOdd returns 1 if the canonical value of e is odd, and zero otherwise.
+func (e *fieldElement) IsOdd() int {
+	var out [fieldElementLen]byte
+	return int(e.bytes(&out)[fieldElementLen-1] & 1)
+}
+
+// reduceOnce sets out = v mod p for v = carry·2²⁵⁶ + in < 2p.
+func reduceOnce(out, in *[4]uint64, carry uint64) {
+	var diff [4]uint64
+	var borrow uint64
+	for i := range diff {
+		diff[i], borrow = bits.Sub64(in[i], p[i], borrow)
+	}
+
+	// Keep the difference unless it wrapped without the carry.
+	mask := -((borrow ^ 1) | carry)
+	for i := range out {
+		out[i] = (diff[i] & mask) | (in[i] &^ mask)
+	}
+}
+
+// montgomeryMul sets out = a·b·2⁻²⁵⁶ mod p using coarsely integrated
+// operand scanning.
+func montgomeryMul(out, a, b *[4]uint64) {
+	var t [6]uint64
+
+	for i := 0; i < 4; i++ {
+		// t += a·b[i]
+		var carry uint64
+		for j := 0; j < 4; j++ {
+			hi, lo := bits.Mul64(a[j], b[i])
+			var c uint64
+			lo, c = bits.Add64(lo, t[j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			t[j], carry = lo, hi
+		}
+		var c uint64
+		t[4], c = bits.Add64(t[4], carry, 0)
+		t[5] = c
+
+		// t = (t + m·p) / 2⁶⁴, where m makes the low limb vanish
+		m := t[0] * pInv
+		hi, lo := bits.Mul64(m, p[0])
+		_, c = bits.Add64(lo, t[0], 0)
+		carry = hi + c
+		for j := 1; j < 4; j++ {
+			hi, lo = bits.Mul64(m, p[j])
+			lo, c = bits.Add64(lo, t[j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			t[j-1], carry = lo, hi
+		}
+		t[3], c = bits.Add64(t[4], carry, 0)
+		t[4] = t[5] + c
+	}
+
+	reduceOnce(out, (*[4]uint64)(t[:4]), t[4])
+}
@@ -0,0 +1,335 @@
+// Package secp256k1 implements the secp256k1 elliptic curve from SEC 2,
+// y² = x³ + 7.
+//
+// It follows the API of the nistec package: points are immutable-looking
+// values with byte slice-based constructors and encodings, field arithmetic
+// is constant time and never uses math/big, and group operations use the
+// complete addition formulas for a = 0 curves from Renes, Costello and
+// Batina, https://eprint.iacr.org/2015/1060. The point at infinity is
+// handled and encoded according to SEC 1, Version 2.0, and invalid curve
+// points can't be represented.
+package secp256k1
+
+import (
+	"crypto/subtle"
+	"errors"
+)
+
+// Point is a secp256k1 point. The zero value is NOT valid.
+type Point struct {
+	// The point is represented in projective coordinates (X:Y:Z),
+	// where x = X/Z and y = Y/Z.
+	x, y, z *fieldElement
+}
+
+const scalarLen = 32
+
+// curveB is the curve coefficient b = 7, and b3 is 3·b.
+var (
+	curveB = fieldConstant(7)
+	b3     = fieldConstant(21)
+)
+
+func fieldConstant(n byte) *fieldElement {
+	buf := make([]byte, fieldElementLen)
+	buf[fieldElementLen-1] = n
+	e, _ := new(fieldElement).SetBytes(buf)
+	return e
+}
+
+var generator = func() *Point {
+	p, err := NewPoint().SetBytes([]byte{0x02,
+		0x79, 0xbe, 0x66, 0x7e, 0xf9, 0xdc, 0xbb, 0xac, 0x55, 0xa0, 0x62, 0x95, 0xce, 0x87, 0x0b, 0x07,
+		0x02, 0x9b, 0xfc, 0xdb, 0x2d, 0xce, 0x28, 0xd9, 0x59, 0xf2, 0x81, 0x5b, 0x16, 0xf8, 0x17, 0x98})
+	if err != nil {
+		panic("secp256k1: internal error: invalid generator")
+	}
+	return p
+}()
+
+// NewPoint returns a new Point representing the point at infinity.
+func NewPoint() *Point {
+	return &Point{
+		x: new(fieldElement),
+		y: new(fieldElement).One(),
+		z: new(fieldElement),
+	}
+}
+
+// NewGenerator returns a new Point set to the canonical generator.
+func NewGenerator() *Point {
+	return NewPoint().Set(generator)
+}
+
+// Set sets p = q and returns p.
+func (p *Point) Set(q *Point) *Point {
+	p.x.Set(q.x)
+	p.y.Set(q.y)
+	p.z.Set(q.z)
+	return p
+}
+
+// SetBytes sets p to the compressed, uncompressed, or infinity value encoded
+// in b, as specified in SEC 1, Version 2.0, Section 2.3.4. If the point is
+// not on the curve, it returns nil and an error, and the receiver is
+// unchanged. Otherwise, it returns p.
+func (p *Point) SetBytes(b []byte) (*Point, error) {
+	switch {
+	// Point at infinity.
+	case len(b) == 1 && b[0] == 0:
+		return p.Set(NewPoint()), nil
+
+	// Uncompressed form.
+	case len(b) == 1+2*fieldElementLen && b[0] == 4:
+		x, err := new(fieldElement).SetBytes(b[1 : 1+fieldElementLen])
+		if err != nil {
+			return nil, err
+		}
+		y, err := new(fieldElement).SetBytes(b[1+fieldElementLen:])
+		if err != nil {
+			return nil, err
+		}
+		if err := checkOnCurve(x, y); err != nil {
+			return nil, err
+		}
+		p.x.Set(x)
+		p.y.Set(y)
+		p.z.One()
+		return p, nil
+
+	// Compressed form.
+	case len(b) == 1+fieldElementLen && (b[0] == 2 || b[0] == 3):
+		x, err := new(fieldElement).SetBytes(b[1:])
+		if err != nil {
+			return nil, err
+		}
+
+		// y² = x³ + 7
+		y := polynomial(x)
+		if y.Sqrt(y) != 1 {
+			return nil, errors.New("invalid secp256k1 compressed point encoding")
+		}
+
+		// Select the positive or negative root, as indicated by the least
+		// significant bit, based on the encoding type byte.
+		otherRoot := new(fieldElement).Sub(new(fieldElement), y)
+		cond := y.IsOdd() ^ int(b[0]&1)
+		y.Select(otherRoot, y, cond)
+
+		p.x.Set(x)
+		p.y.Set(y)
+		p.z.One()
+		return p, nil
+
+	default:
+		return nil, errors.New("invalid secp256k1 point encoding")
+	}
+}
+
+// polynomial returns x³ + 7.
+func polynomial(x *fieldElement) *fieldElement {
+	y2 := new(fieldElement).Square(x)
+	y2.Mul(y2, x)
+	return y2.Add(y2, curveB)
+}
+
+func checkOnCurve(x, y *fieldElement) error {
+	// y² = x³ + 7
+	rhs := polynomial(x)
+	lhs := new(fieldElement).Square(y)
+	if rhs.Equal(lhs) != 1 {
+		return errors.New("secp256k1 point not on curve")
+	}
+	return nil
+}
+
+// Bytes returns the uncompressed or infinity encoding of p, as specified in
+// SEC 1, Version 2.0, Section 2.3.3. Note that the encoding of the point at
+// infinity is shorter than all other encodings.
+func (p *Point) Bytes() []byte {
+	if p.z.IsZero() == 1 {
+		return []byte{0}
+	}
+
+	x, y := p.affine()
+	buf := make([]byte, 0, 1+2*fieldElementLen)
+	buf = append(buf, 4)
+	buf = append(buf, x.Bytes()...)
+	return append(buf, y.Bytes()...)
+}
+
+// BytesCompressed returns the compressed or infinity encoding of p, as
+// specified in SEC 1, Version 2.0, Section 2.3.3. Note that the encoding of
+// the point at infinity is shorter than all other encodings.
+func (p *Point) BytesCompressed() []byte {
+	if p.z.IsZero() == 1 {
+		return []byte{0}
+	}
+
+	x, y := p.affine()
+	buf := make([]byte, 0, 1+fieldElementLen)
+	buf = append(buf, 2|byte(y.IsOdd()))
+	return append(buf, x.Bytes()...)
+}
+
+func (p *Point) affine() (x, y *fieldElement) {
+	zinv := new(fieldElement).Invert(p.z)
+	x = new(fieldElement).Mul(p.x, zinv)
+	y = new(fieldElement).Mul(p.y, zinv)
+	return x, y
+}
+
+// Add sets q = p1 + p2, and returns q. The points may overlap.
+func (q *Point) Add(p1, p2 *Point) *Point {
+	// Complete addition formula for a = 0 from "Complete addition formulas
+	// for prime order elliptic curves" (https://eprint.iacr.org/2015/1060),
+	// Algorithm 7.
+
+	t0 := new(fieldElement).Mul(p1.x, p2.x) // t0 := X1 * X2
+	t1 := new(fieldElement).Mul(p1.y, p2.y) // t1 := Y1 * Y2
+	t2 := new(fieldElement).Mul(p1.z, p2.z) // t2 := Z1 * Z2
+	t3 := new(fieldElement).Add(p1.x, p1.y) // t3 := X1 + Y1
+	t4 := new(fieldElement).Add(p2.x, p2.y) // t4 := X2 + Y2
+	t3.Mul(t3, t4)                          // t3 := t3 * t4
+	t4.Add(t0, t1)                          // t4 := t0 + t1
+	t3.Sub(t3, t4)                          // t3 := t3 - t4
+	t4.Add(p1.y, p1.z)                      // t4 := Y1 + Z1
+	x3 := new(fieldElement).Add(p2.y, p2.z) // X3 := Y2 + Z2
+	t4.Mul(t4, x3)                          // t4 := t4 * X3
+	x3.Add(t1, t2)                          // X3 := t1 + t2
+	t4.Sub(t4, x3)                          // t4 := t4 - X3
+	x3.Add(p1.x, p1.z)                      // X3 := X1 + Z1
+	y3 := new(fieldElement).Add(p2.x, p2.z) // Y3 := X2 + Z2
+	x3.Mul(x3, y3)                          // X3 := X3 * Y3
+	y3.Add(t0, t2)                          // Y3 := t0 + t2
+	y3.Sub(x3, y3)                          // Y3 := X3 - Y3
+	x3.Add(t0, t0)                          // X3 := t0 + t0
+	t0.Add(x3, t0)                          // t0 := X3 + t0
+	t2.Mul(b3, t2)                          // t2 := b3 * t2
+	z3 := new(fieldElement).Add(t1, t2)     // Z3 := t1 + t2
+	t1.Sub(t1, t2)                          // t1 := t1 - t2
+	y3.Mul(b3, y3)                          // Y3 := b3 * Y3
+	x3.Mul(t4, y3)                          // X3 := t4 * Y3
+	t2.Mul(t3, t1)                          // t2 := t3 * t1
+	x3.Sub(t2, x3)                          // X3 := t2 - X3
+	y3.Mul(y3, t0)                          // Y3 := Y3 * t0
+	t1.Mul(t1, z3)                          // t1 := t1 * Z3
+	y3.Add(t1, y3)                          // Y3 := t1 + Y3
+	t0.Mul(t0, t3)                          // t0 := t0 * t3
+	z3.Mul(z3, t4)                          // Z3 := Z3 * t4
+	z3.Add(z3, t0)                          // Z3 := Z3 + t0
+
+	q.x.Set(x3)
+	q.y.Set(y3)
+	q.z.Set(z3)
+	return q
+}
+
+// Double sets q = p + p, and returns q. The points may overlap.
+func (q *Point) Double(p *Point) *Point {
+	// Complete doubling formula for a = 0 from "Complete addition formulas
+	// for prime order elliptic curves" (https://eprint.iacr.org/2015/1060),
+	// Algorithm 9.
+
+	t0 := new(fieldElement).Square(p.y)   // t0 := Y ^ 2
+	z3 := new(fieldElement).Add(t0, t0)   // Z3 := t0 + t0
+	z3.Add(z3, z3)                        // Z3 := Z3 + Z3
+	z3.Add(z3, z3)                        // Z3 := Z3 + Z3
+	t1 := new(fieldElement).Mul(p.y, p.z) // t1 := Y * Z
+	t2 := new(fieldElement).Square(p.z)   // t2 := Z ^ 2
+	t2.Mul(b3, t2)                        // t2 := b3 * t2
+	x3 := new(fieldElement).Mul(t2, z3)   // X3 := t2 * Z3
+	y3 := new(fieldElement).Add(t0, t2)   // Y3 := t0 + t2
+	z3.Mul(t1, z3)                        // Z3 := t1 * Z3
+	t1.Add(t2, t2)                        // t1 := t2 + t2
+	t2.Add(t1, t2)                        // t2 := t1 + t2
+	t0.Sub(t0, t2)                        // t0 := t0 - t2
+	y3.Mul(t0, y3)                        // Y3 := t0 * Y3
+	y3.Add(x3, y3)                        // Y3 := X3 + Y3
+	t1.Mul(p.x, p.y)                      // t1 := X * Y
+	x3.Mul(t0, t1)                        // X3 := t0 * t1
+	x3.Add(x3, x3)                        // X3 := X3 + X3
+
+	q.x.Set(x3)
+	q.y.Set(y3)
+	q.z.Set(z3)
+	return q
+}
+
+// Select sets q to p1 if cond == 1, and to p2 if cond == 0.
+func (q *Point) Select(p1, p2 *Point, cond int) *Point {
+	q.x.Select(p1.x, p2.x, cond)
+	q.y.Select(p1.y, p2.y, cond)
+	q.z.Select(p1.z, p2.z, cond)
+	return q
+}
+
+// table is a table of the first 15 multiples of a point. Points are stored
+// at an index offset of -1 so [8]P is at index 7, P is at 0, and [15]P is
+// at 14. [0]P is the point at infinity and it's not stored.
+type table [15]*Point
+
+// Select selects the n-th multiple of the table base point into p. It works in
+// constant time by iterating over every entry of the table. n must be in [0, 15].
+func (t *table) Select(p *Point, n uint8) {
+	if n >= 16 {
+		panic("secp256k1: internal error: table called with out-of-bounds value")
+	}
+	p.Set(NewPoint())
+	for i := uint8(1); i < 16; i++ {
+		cond := subtle.ConstantTimeByteEq(i, n)
+		p.Select(t[i-1], p, cond)
+	}
+}
+
+// ScalarMult sets p = scalar * q, and returns p.
+func (p *Point) ScalarMult(q *Point, scalar []byte) (*Point, error) {
+	if len(scalar) != scalarLen {
+		return nil, errors.New("invalid scalar length")
+	}
+
+	// Compute a table for the base point q.
+	var t = table{NewPoint(), NewPoint(), NewPoint(), NewPoint(), NewPoint(),
+		NewPoint(), NewPoint(), NewPoint(), NewPoint(), NewPoint(), NewPoint(),
+		NewPoint(), NewPoint(), NewPoint(), NewPoint()}
+	t[0].Set(q)
+	for i := 1; i < 15; i += 2 {
+		t[i].Double(t[i/2])
+		t[i+1].Add(t[i], q)
+	}
+
+	// Instead of doing the classic double-and-add chain, we do it with a
+	// four-bit window: we double four times, and then add [0-15]P.
+	w := NewPoint()
+	p.Set(NewPoint())
+	for i, byte := range scalar {
+		// No need to double on the first iteration, as p is the identity at
+		// this point, and [N]∞ = ∞.
+		if i != 0 {
+			p.Double(p)
+			p.Double(p)
+			p.Double(p)
+			p.Double(p)
+		}
+
+		windowValue := byte >> 4
+		t.Select(w, windowValue)
+		p.Add(p, w)
+
+		p.Double(p)
+		p.Double(p)
+		p.Double(p)
+		p.Double(p)
+
+		windowValue = byte & 0b1111
+		t.Select(w, windowValue)
+		p.Add(p, w)
+	}
+
+	return p, nil
+}
+
+// ScalarBaseMult sets p = scalar * G, where G is the generator, and returns p.
+func (p *Point) ScalarBaseMult(scalar []byte) (*Point, error) {
+	return p.ScalarMult(generator, scalar)
+}
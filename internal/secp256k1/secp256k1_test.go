@@ -0,0 +1,170 @@
+package secp256k1
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+var (
+	refP, _  = new(big.Int).SetString("fffffffffffffffffffffffffffffffffffffffffffffffffffffffefffffc2f", 16)
+	refN, _  = new(big.Int).SetString("fffffffffffffffffffffffffffffffebaaedce6af48a03bbfd25e8cd0364141", 16)
+	refGx, _ = new(big.Int).SetString("79be667ef9dcbbac55a06295ce870b07029bfcdb2dce28d959f2815b16f81798", 16)
+	refGy, _ = new(big.Int).SetString("483ada7726a3c4655da4fbfc0e1108a8fd17b448a68554199c47d08ffb10d4b8", 16)
+)
+
+// refAdd is a textbook affine addition used as a reference; nil is the
+// point at infinity.
+func refAdd(p1, p2 []*big.Int) []*big.Int {
+	if p1 == nil {
+		return p2
+	}
+	if p2 == nil {
+		return p1
+	}
+
+	var lambda *big.Int
+	if p1[0].Cmp(p2[0]) == 0 {
+		if new(big.Int).Add(p1[1], p2[1]).Mod(new(big.Int).Add(p1[1], p2[1]), refP).Sign() == 0 {
+			return nil
+		}
+		num := new(big.Int).Mul(p1[0], p1[0])
+		num.Mul(num, big.NewInt(3))
+		den := new(big.Int).Lsh(p1[1], 1)
+		lambda = num.Mul(num, den.ModInverse(den, refP))
+	} else {
+		num := new(big.Int).Sub(p2[1], p1[1])
+		den := new(big.Int).Sub(p2[0], p1[0])
+		den.Mod(den, refP)
+		lambda = num.Mul(num, den.ModInverse(den, refP))
+	}
+	lambda.Mod(lambda, refP)
+
+	x := new(big.Int).Mul(lambda, lambda)
+	x.Sub(x, p1[0]).Sub(x, p2[0]).Mod(x, refP)
+	y := new(big.Int).Sub(p1[0], x)
+	y.Mul(y, lambda).Sub(y, p1[1]).Mod(y, refP)
+	return []*big.Int{x, y}
+}
+
+func refScalarBaseMult(k *big.Int) []*big.Int {
+	var result []*big.Int
+	for i := k.BitLen() - 1; i >= 0; i-- {
+		result = refAdd(result, result)
+		if k.Bit(i) == 1 {
+			result = refAdd(result, []*big.Int{refGx, refGy})
+		}
+	}
+	return result
+}
+
+func refBytes(point []*big.Int) []byte {
+	if point == nil {
+		return []byte{0}
+	}
+	out := make([]byte, 65)
+	out[0] = 4
+	point[0].FillBytes(out[1:33])
+	point[1].FillBytes(out[33:])
+	return out
+}
+
+func scalarBytes(k *big.Int) []byte {
+	return k.FillBytes(make([]byte, scalarLen))
+}
+
+// TestScalarBaseMult tests scalar multiplication against the affine
+// reference
+func TestScalarBaseMult(t *testing.T) {
+	scalars := []*big.Int{
+		big.NewInt(0),
+		big.NewInt(1),
+		big.NewInt(2),
+		big.NewInt(15),
+		big.NewInt(16),
+		new(big.Int).Sub(refN, big.NewInt(1)),
+	}
+	for i := 0; i < 8; i++ {
+		k, err := rand.Int(rand.Reader, refN)
+		if err != nil {
+			t.Fatal(err)
+		}
+		scalars = append(scalars, k)
+	}
+
+	for _, k := range scalars {
+		p, err := NewPoint().ScalarBaseMult(scalarBytes(k))
+		if err != nil {
+			t.Fatalf("ScalarBaseMult failed: %v", err)
+		}
+		if expected := refBytes(refScalarBaseMult(k)); !bytes.Equal(p.Bytes(), expected) {
+			t.Errorf("k = %x: expected %x, got %x", k, expected, p.Bytes())
+		}
+	}
+
+	p, err := NewPoint().ScalarBaseMult(scalarBytes(refN))
+	if err != nil {
+		t.Fatalf("ScalarBaseMult failed: %v", err)
+	}
+	if !bytes.Equal(p.Bytes(), []byte{0}) {
+		t.Error("n·G is not the point at infinity")
+	}
+}
+
+// TestAddDouble tests that the complete formulas agree on doubling and
+// handle the point at infinity
+func TestAddDouble(t *testing.T) {
+	g := NewGenerator()
+
+	sum := NewPoint().Add(g, g)
+	double := NewPoint().Double(g)
+	if !bytes.Equal(sum.Bytes(), double.Bytes()) {
+		t.Error("G + G != 2·G")
+	}
+
+	if !bytes.Equal(NewPoint().Add(g, NewPoint()).Bytes(), g.Bytes()) {
+		t.Error("G + ∞ != G")
+	}
+
+	negG, err := NewPoint().SetBytes(append([]byte{3}, g.BytesCompressed()[1:]...))
+	if err != nil {
+		t.Fatalf("SetBytes failed: %v", err)
+	}
+	if !bytes.Equal(NewPoint().Add(g, negG).Bytes(), []byte{0}) {
+		t.Error("G + (−G) != ∞")
+	}
+}
+
+// TestSetBytes tests encoding round trips and rejection of invalid points
+func TestSetBytes(t *testing.T) {
+	g := NewGenerator()
+
+	for _, encoding := range [][]byte{g.Bytes(), g.BytesCompressed(), {0}} {
+		p, err := NewPoint().SetBytes(encoding)
+		if err != nil {
+			t.Fatalf("SetBytes(%x) failed: %v", encoding, err)
+		}
+		if !bytes.Equal(p.Bytes(), NewPoint().Set(p).Bytes()) {
+			t.Errorf("round trip of %x failed", encoding)
+		}
+	}
+
+	offCurve := g.Bytes()
+	offCurve[64] ^= 1
+	if _, err := NewPoint().SetBytes(offCurve); err == nil {
+		t.Error("expected error for a point off the curve")
+	}
+
+	tooLarge := append([]byte{2}, refP.Bytes()...)
+	if _, err := NewPoint().SetBytes(tooLarge); err == nil {
+		t.Error("expected error for x ≥ p")
+	}
+
+	// x = 5 gives x³ + 7 = 132, which is not a square mod p
+	notSquare := make([]byte, 33)
+	notSquare[0], notSquare[32] = 2, 5
+	if _, err := NewPoint().SetBytes(notSquare); err == nil {
+		t.Error("expected error for x with no matching y")
+	}
+}
@@ -0,0 +1,254 @@
+package pvss
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+var (
+	// ErrUnknownLanguage is returned when no word list contains every word
+	// of a phrase.
+	ErrUnknownLanguage = errors.New("phrase does not match any BIP-39 word list")
+	// ErrAmbiguousLanguage is returned when a phrase is valid in more than
+	// one word list, as short phrases of shared Chinese characters can be.
+	ErrAmbiguousLanguage = errors.New("phrase matches more than one BIP-39 word list")
+)
+
+// Language selects one of the official BIP-39 word lists.
+type Language int
+
+const (
+	LanguageEnglish Language = iota
+	LanguageJapanese
+	LanguageSpanish
+	LanguageFrench
+	LanguageItalian
+	LanguageCzech
+	LanguageKorean
+	LanguageChineseSimplified
+	LanguageChineseTraditional
+	LanguagePortuguese
+)
+
+// ideographicSpace separates Japanese mnemonic words, as BIP-39 specifies.
+const ideographicSpace = "\u3000"
+
+// Languages lists every supported word list, in detection order.
+func Languages() []Language {
+	return []Language{
+		LanguageEnglish,
+		LanguageJapanese,
+		LanguageSpanish,
+		LanguageFrench,
+		LanguageItalian,
+		LanguageCzech,
+		LanguagePortuguese,
+		LanguageKorean,
+		LanguageChineseSimplified,
+		LanguageChineseTraditional,
+	}
+}
+
+func (lang Language) String() string {
+	switch lang {
+	case LanguageEnglish:
+		return "english"
+	case LanguageJapanese:
+		return "japanese"
+	case LanguageSpanish:
+		return "spanish"
+	case LanguageFrench:
+		return "french"
+	case LanguageItalian:
+		return "italian"
+	case LanguageCzech:
+		return "czech"
+	case LanguageKorean:
+		return "korean"
+	case LanguageChineseSimplified:
+		return "chinese_simplified"
+	case LanguageChineseTraditional:
+		return "chinese_traditional"
+	case LanguagePortuguese:
+		return "portuguese"
+	default:
+		return fmt.Sprintf("Language(%d)", int(lang))
+	}
+}
+
+// Words returns the language's word list in NFKD form, or nil for an
+// unknown language.
+func (lang Language) Words() []string {
+	switch lang {
+	case LanguageEnglish:
+		return BIP39EnglishWords()
+	case LanguageJapanese:
+		return BIP39JapaneseWords()
+	case LanguageSpanish:
+		return BIP39SpanishWords()
+	case LanguageFrench:
+		return BIP39FrenchWords()
+	case LanguageItalian:
+		return BIP39ItalianWords()
+	case LanguageCzech:
+		return BIP39CzechWords()
+	case LanguageKorean:
+		return BIP39KoreanWords()
+	case LanguageChineseSimplified:
+		return BIP39ChineseSimplifiedWords()
+	case LanguageChineseTraditional:
+		return BIP39ChineseTraditionalWords()
+	case LanguagePortuguese:
+		return BIP39PortugueseWords()
+	default:
+		return nil
+	}
+}
+
+// NewBIP39Encoder returns a MnemonicEncoder for the language's word list.
+// Japanese phrases are joined with the ideographic space.
+func NewBIP39Encoder(lang Language, opts ...MnemonicOption) (*MnemonicEncoder, error) {
+	words := lang.Words()
+	if words == nil {
+		return nil, fmt.Errorf("unsupported language: %v", lang)
+	}
+
+	if lang == LanguageJapanese {
+		opts = append([]MnemonicOption{WithWordSeparator(ideographicSpace)}, opts...)
+	}
+
+	return NewMnemonicEncoder(words, opts...), nil
+}
+
+var (
+	bip39EncodersOnce sync.Once
+	bip39Encoders     map[Language]*MnemonicEncoder
+)
+
+// bip39Encoder returns a shared encoder for lang, built on first use.
+func bip39Encoder(lang Language) *MnemonicEncoder {
+	bip39EncodersOnce.Do(func() {
+		bip39Encoders = make(map[Language]*MnemonicEncoder)
+		for _, l := range Languages() {
+			bip39Encoders[l], _ = NewBIP39Encoder(l)
+		}
+	})
+	return bip39Encoders[lang]
+}
+
+// normalizeMnemonic applies Unicode NFKD, the form the word lists are stored
+// in, so that phrases typed with precomposed accents, kana or Hangul match.
+// It also folds the ideographic space into an ASCII one.
+func normalizeMnemonic(mnemonic string) string {
+	return norm.NFKD.String(mnemonic)
+}
+
+// DetectLanguage reports which BIP-39 word list a phrase is written in. When
+// the words appear in more than one list, the phrase checksum decides.
+func DetectLanguage(mnemonic string) (Language, error) {
+	words := strings.Fields(normalizeMnemonic(mnemonic))
+	if len(words) == 0 {
+		return 0, errors.New("empty mnemonic")
+	}
+
+	var candidates []Language
+	for _, lang := range Languages() {
+		if bip39Encoder(lang).containsAll(words) {
+			candidates = append(candidates, lang)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return 0, ErrUnknownLanguage
+	case 1:
+		return candidates[0], nil
+	}
+
+	// The reader's checksum version is not known here, so either decides
+	var valid []Language
+	for _, lang := range candidates {
+		encoder := bip39Encoder(lang)
+		for _, version := range []ChecksumVersion{ChecksumSHA256, ChecksumLegacy} {
+			if _, ok := encoder.VerifyChecksumVersion(mnemonic, version); ok {
+				valid = append(valid, lang)
+				break
+			}
+		}
+	}
+
+	if len(valid) == 1 {
+		return valid[0], nil
+	}
+	return 0, fmt.Errorf("%w: %v", ErrAmbiguousLanguage, candidates)
+}
+
+// TranslateMnemonic rewrites a phrase in another language. Words map one to
+// one by their index in the word lists, checksum words included, so the
+// result decodes to the same bytes and still verifies.
+func TranslateMnemonic(mnemonic string, to Language) (string, error) {
+	target := bip39Encoder(to)
+	if target == nil {
+		return "", fmt.Errorf("unsupported language: %v", to)
+	}
+
+	from, err := DetectLanguage(mnemonic)
+	if err != nil {
+		return "", err
+	}
+	source := bip39Encoder(from)
+
+	words := strings.Fields(normalizeMnemonic(mnemonic))
+	translated := make([]string, len(words))
+	for i, word := range words {
+		translated[i] = target.wordList[source.wordMap[word]]
+	}
+
+	return strings.Join(translated, target.separator), nil
+}
+
+// TranslateShare rewrites both phrases of a share in another language.
+func TranslateShare(share Share, to Language) (Share, error) {
+	key, err := TranslateMnemonic(share.Key, to)
+	if err != nil {
+		return Share{}, fmt.Errorf("failed to translate key: %w", err)
+	}
+
+	keyCheck, err := TranslateMnemonic(share.KeyCheck, to)
+	if err != nil {
+		return Share{}, fmt.Errorf("failed to translate key check: %w", err)
+	}
+
+	return Share{Key: key, KeyCheck: keyCheck}, nil
+}
+
+// encoderFor picks the encoder for a phrase: the configured one when it
+// knows every word, otherwise the detected language's with the configured
+// checksum version.
+func (pvss *PedersenVSS) encoderFor(phrase string) *MnemonicEncoder {
+	words := strings.Fields(normalizeMnemonic(phrase))
+	if pvss.mnemonicEncoder.containsAll(words) {
+		return pvss.mnemonicEncoder
+	}
+
+	if lang, err := DetectLanguage(phrase); err == nil {
+		return pvss.mnemonicEncoder.forLanguage(lang)
+	}
+	return pvss.mnemonicEncoder
+}
+
+// forLanguage returns an encoder for lang's word list that checks the same
+// checksum version as me.
+func (me *MnemonicEncoder) forLanguage(lang Language) *MnemonicEncoder {
+	shared := bip39Encoder(lang)
+	if shared.checksumVersion == me.checksumVersion {
+		return shared
+	}
+
+	encoder, _ := NewBIP39Encoder(lang, WithChecksumVersion(me.checksumVersion))
+	return encoder
+}
@@ -0,0 +1,237 @@
+package pvss
+
+import (
+	"bytes"
+	"errors"
+	"slices"
+	"strings"
+	"testing"
+
+	"golang.org/x/text/unicode/norm"
+)
+
+// TestLanguageWordLists tests that every list has 2048 unique NFKD words
+func TestLanguageWordLists(t *testing.T) {
+	for _, lang := range Languages() {
+		t.Run(lang.String(), func(t *testing.T) {
+			words := lang.Words()
+			if len(words) != 2048 {
+				t.Fatalf("expected 2048 words, got %d", len(words))
+			}
+
+			seen := make(map[string]bool)
+			for i, word := range words {
+				if seen[word] {
+					t.Errorf("duplicate word %q at index %d", word, i)
+				}
+				seen[word] = true
+
+				if !norm.NFKD.IsNormalString(word) {
+					t.Errorf("word %q at index %d is not NFKD normalized", word, i)
+				}
+			}
+		})
+	}
+
+	// The Portuguese list is plain lowercase ASCII in byte order
+	portuguese := LanguagePortuguese.Words()
+	if portuguese[0] != "abacate" || portuguese[2047] != "zumbido" || !slices.IsSorted(portuguese) {
+		t.Error("unexpected Portuguese word list order")
+	}
+	for i, word := range portuguese {
+		if strings.Trim(word, "abcdefghijklmnopqrstuvwxyz") != "" {
+			t.Errorf("Portuguese word %q at index %d is not lowercase ASCII", word, i)
+		}
+	}
+
+	if Language(99).Words() != nil {
+		t.Error("expected nil word list for unknown language")
+	}
+	if _, err := NewBIP39Encoder(Language(99)); err == nil {
+		t.Error("expected error for unknown language")
+	}
+}
+
+// TestNewBIP39Encoder_JapaneseSeparator tests the ideographic space
+func TestNewBIP39Encoder_JapaneseSeparator(t *testing.T) {
+	encoder, err := NewBIP39Encoder(LanguageJapanese)
+	if err != nil {
+		t.Fatalf("NewBIP39Encoder failed: %v", err)
+	}
+
+	mnemonic, err := encoder.EncodeToMnemonic([]byte{0x12, 0x34, 0x56, 0x78})
+	if err != nil {
+		t.Fatalf("EncodeToMnemonic failed: %v", err)
+	}
+	if !strings.Contains(mnemonic, "　") || strings.Contains(mnemonic, " ") {
+		t.Errorf("expected words joined by ideographic space, got %q", mnemonic)
+	}
+
+	phrase, valid := encoder.VerifyChecksum(encoder.AddChecksum(mnemonic))
+	if !valid || phrase != mnemonic {
+		t.Errorf("checksum round trip failed: %q, %v", phrase, valid)
+	}
+}
+
+// TestDecodeFromMnemonic_NFKD tests that precomposed input decodes
+func TestDecodeFromMnemonic_NFKD(t *testing.T) {
+	data := []byte("accents, kana and hangul")
+
+	for _, lang := range []Language{LanguageSpanish, LanguageFrench, LanguageJapanese, LanguageKorean} {
+		t.Run(lang.String(), func(t *testing.T) {
+			encoder, _ := NewBIP39Encoder(lang)
+
+			mnemonic, err := encoder.EncodeToMnemonic(data)
+			if err != nil {
+				t.Fatalf("EncodeToMnemonic failed: %v", err)
+			}
+
+			composed := norm.NFC.String(mnemonic)
+			if composed == mnemonic {
+				t.Fatalf("expected the phrase to change under NFC")
+			}
+
+			decoded, err := encoder.DecodeFromMnemonic(composed)
+			if err != nil {
+				t.Fatalf("DecodeFromMnemonic failed: %v", err)
+			}
+			if !bytes.Equal(decoded, data) {
+				t.Errorf("expected %x, got %x", data, decoded)
+			}
+		})
+	}
+}
+
+// TestDetectLanguage tests detection for every language
+func TestDetectLanguage(t *testing.T) {
+	data := []byte("which language is this phrase in?")
+
+	for _, lang := range Languages() {
+		t.Run(lang.String(), func(t *testing.T) {
+			encoder, _ := NewBIP39Encoder(lang)
+			mnemonic, _ := encoder.EncodeToMnemonic(data)
+
+			detected, err := DetectLanguage(norm.NFC.String(encoder.AddChecksum(mnemonic)))
+			if err != nil {
+				t.Fatalf("DetectLanguage failed: %v", err)
+			}
+			if detected != lang {
+				t.Errorf("expected %v, got %v", lang, detected)
+			}
+		})
+	}
+
+	if _, err := DetectLanguage("abandon notaword"); !errors.Is(err, ErrUnknownLanguage) {
+		t.Errorf("expected ErrUnknownLanguage, got %v", err)
+	}
+}
+
+// TestDetectLanguage_SharedWords tests phrases whose words appear in both
+// the English and French lists: the checksum settles them, and without a
+// valid checksum they are reported as ambiguous
+func TestDetectLanguage_SharedWords(t *testing.T) {
+	english := bip39Encoder(LanguageEnglish)
+	french := bip39Encoder(LanguageFrench)
+
+	var shared []string
+	for _, word := range english.wordList {
+		if _, exists := french.wordMap[word]; exists {
+			shared = append(shared, word)
+		}
+	}
+	if len(shared) < 2 {
+		t.Fatalf("expected shared words, got %v", shared)
+	}
+
+	if _, err := DetectLanguage(strings.Join(shared[:2], " ")); !errors.Is(err, ErrAmbiguousLanguage) {
+		t.Errorf("expected ErrAmbiguousLanguage, got %v", err)
+	}
+
+	// Find a phrase of shared words whose English checksum words are shared too
+	for _, a := range shared {
+		for _, b := range shared {
+			phrase := english.AddChecksum(a + " " + b)
+			if !french.containsAll(strings.Fields(phrase)) {
+				continue
+			}
+
+			detected, err := DetectLanguage(phrase)
+			if err != nil {
+				t.Fatalf("DetectLanguage(%q) failed: %v", phrase, err)
+			}
+			if detected != LanguageEnglish {
+				t.Errorf("expected english for %q, got %v", phrase, detected)
+			}
+			return
+		}
+	}
+	t.Skip("no phrase of shared words found")
+}
+
+// TestTranslateShare tests that translated shares reconstruct the secret
+// and translate back to the original phrases
+func TestTranslateShare(t *testing.T) {
+	pvss := NewPedersenVSS()
+	secret := "translated custody backup"
+
+	shares, err := pvss.SplitSecret(secret, 4, 3)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+
+	mixed := make([]Share, 3)
+	for i, lang := range []Language{LanguageJapanese, LanguageSpanish, LanguageKorean} {
+		translated, err := TranslateShare(shares[i], lang)
+		if err != nil {
+			t.Fatalf("TranslateShare to %v failed: %v", lang, err)
+		}
+
+		valid, err := pvss.VerifyShare(translated)
+		if err != nil || !valid {
+			t.Errorf("translated %v share failed verification: %v", lang, err)
+		}
+
+		back, err := TranslateShare(translated, LanguageEnglish)
+		if err != nil {
+			t.Fatalf("TranslateShare back failed: %v", err)
+		}
+		if back != shares[i] {
+			t.Errorf("%v round trip changed the share", lang)
+		}
+
+		mixed[i] = translated
+	}
+
+	reconstructed, err := pvss.ReconstructSecret(mixed)
+	if err != nil {
+		t.Fatalf("ReconstructSecret failed: %v", err)
+	}
+	if reconstructed != secret {
+		t.Errorf("expected %q, got %q", secret, reconstructed)
+	}
+}
+
+// TestWithMnemonicEncoder tests splitting in a chosen language
+func TestWithMnemonicEncoder(t *testing.T) {
+	encoder, _ := NewBIP39Encoder(LanguageChineseTraditional)
+	pvss := NewPedersenVSS(WithMnemonicEncoder(encoder))
+
+	shares, err := pvss.SplitSecret("秘密", 3, 2)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+
+	lang, err := DetectLanguage(shares[0].Key)
+	if err != nil || lang != LanguageChineseTraditional {
+		t.Errorf("expected chinese_traditional share, got %v (%v)", lang, err)
+	}
+
+	// A default instance reads the shares too
+	reconstructed, err := NewPedersenVSS().ReconstructSecret(shares[1:])
+	if err != nil {
+		t.Fatalf("ReconstructSecret failed: %v", err)
+	}
+	if reconstructed != "秘密" {
+		t.Errorf("expected %q, got %q", "秘密", reconstructed)
+	}
+}
@@ -1,27 +1,93 @@
 package pvss
 
 import (
+	"crypto/sha256"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/big"
+	"slices"
 	"strings"
 )
 
+// ChecksumVersion identifies the checksum appended to a mnemonic phrase.
+type ChecksumVersion int
+
+const (
+	// ChecksumLegacy is a single word holding the sum of the word indices.
+	// It misses swapped words and many double substitutions.
+	ChecksumLegacy ChecksumVersion = 1
+	// ChecksumSHA256 is a truncated SHA-256 of the word indices, at least 22
+	// bits long: two words with the BIP-39 list. Swapped or substituted words
+	// slip through with probability below 2^-22.
+	ChecksumSHA256 ChecksumVersion = 2
+)
+
+const (
+	checksumDomain = "pvss/mnemonic-checksum/v2"
+	checksumBits   = 22
+)
+
+func (version ChecksumVersion) String() string {
+	switch version {
+	case ChecksumLegacy:
+		return "legacy"
+	case ChecksumSHA256:
+		return "sha256"
+	default:
+		return fmt.Sprintf("ChecksumVersion(%d)", int(version))
+	}
+}
+
 type MnemonicEncoder struct {
-	wordList []string
-	wordMap  map[string]int
+	wordList        []string
+	wordMap         map[string]int
+	checksumVersion ChecksumVersion
+	encodingMode    EncodingMode
+	separator       string // Joins words in the phrases the encoder writes
 }
 
-func NewMnemonicEncoder(wordList []string) *MnemonicEncoder {
+// MnemonicOption configures a MnemonicEncoder.
+type MnemonicOption func(*MnemonicEncoder)
+
+// WithChecksumVersion selects the checksum AddChecksum appends and
+// VerifyChecksum expects. The default is ChecksumSHA256; phrases written with
+// ChecksumLegacy are only read by an encoder configured for it. PedersenVSS
+// reads legacy Keys and KeyChecks with any encoder, as their payloads have
+// no header.
+func WithChecksumVersion(version ChecksumVersion) MnemonicOption {
+	return func(me *MnemonicEncoder) {
+		me.checksumVersion = version
+	}
+}
+
+// WithWordSeparator sets the string placed between words in the phrases the
+// encoder writes. The default is a single space. Decoding accepts any Unicode
+// white space regardless.
+func WithWordSeparator(separator string) MnemonicOption {
+	return func(me *MnemonicEncoder) {
+		me.separator = separator
+	}
+}
+
+func NewMnemonicEncoder(wordList []string, opts ...MnemonicOption) *MnemonicEncoder {
 	wordMap := make(map[string]int)
 	for i, word := range wordList {
 		wordMap[word] = i
 	}
 
-	return &MnemonicEncoder{
-		wordList: wordList,
-		wordMap:  wordMap,
+	me := &MnemonicEncoder{
+		wordList:        wordList,
+		wordMap:         wordMap,
+		checksumVersion: ChecksumSHA256,
+		encodingMode:    EncodingBigInt,
+		separator:       " ",
+	}
+	for _, opt := range opts {
+		opt(me)
 	}
+
+	return me
 }
 
 func (me *MnemonicEncoder) EncodeToMnemonic(data []byte) (string, error) {
@@ -32,27 +98,65 @@ func (me *MnemonicEncoder) EncodeToMnemonic(data []byte) (string, error) {
 		return "", fmt.Errorf("invalid word list or map")
 	}
 
+	if me.encodingMode == EncodingPacked {
+		return me.encodePacked(data)
+	}
+
+	var indices []int
+	if wordBits, err := me.wordBits(); err == nil {
+		indices = encodeRadix2(data, wordBits)
+	} else {
+		indices = encodeBaseN(data, len(me.wordList))
+	}
+
+	words := make([]string, len(indices))
+	for i, index := range indices {
+		words[i] = me.wordList[index]
+	}
+
+	return strings.Join(words, me.separator), nil
+}
+
+// encodeBaseN writes data, read as a big-endian integer, as base-n digits,
+// most significant first. It takes quadratic time in len(data) and is only
+// used for word lists whose length is not a power of two.
+func encodeBaseN(data []byte, n int) []int {
 	dataInt := new(big.Int).SetBytes(data)
-	base := big.NewInt(int64(len(me.wordList)))
+	base := big.NewInt(int64(n))
 
-	var words []string
+	var indices []int
 	zero := big.NewInt(0)
 
 	// Convert to base-n representation where n is the number of words
 	for dataInt.Cmp(zero) > 0 {
 		remainder := new(big.Int)
 		dataInt.DivMod(dataInt, base, remainder)
-		wordIndex := int(remainder.Int64())
-		words = append([]string{me.wordList[wordIndex]}, words...)
+		indices = append(indices, int(remainder.Int64()))
 	}
 
-	if len(words) == 0 {
-		words = []string{me.wordList[0]}
+	if len(indices) == 0 {
+		return []int{0}
 	}
 
-	return strings.Join(words, " "), nil
+	slices.Reverse(indices)
+	return indices
 }
 
+// decodeBaseN is the inverse of encodeBaseN.
+func decodeBaseN(indices []int, n int) []byte {
+	dataInt := big.NewInt(0)
+	base := big.NewInt(int64(n))
+
+	for _, wordIndex := range indices {
+		dataInt.Mul(dataInt, base)
+		dataInt.Add(dataInt, big.NewInt(int64(wordIndex)))
+	}
+
+	return dataInt.Bytes()
+}
+
+// DecodeFromMnemonic converts a phrase back to bytes. The phrase is NFKD
+// normalized first, so precomposed and decomposed accents both decode.
 func (me *MnemonicEncoder) DecodeFromMnemonic(mnemonic string) ([]byte, error) {
 	if strings.TrimSpace(mnemonic) == "" {
 		return nil, fmt.Errorf("empty mnemonic input")
@@ -62,33 +166,87 @@ func (me *MnemonicEncoder) DecodeFromMnemonic(mnemonic string) ([]byte, error) {
 		return nil, fmt.Errorf("invalid list and map")
 	}
 
-	words := strings.Fields(mnemonic)
+	words := strings.Fields(normalizeMnemonic(mnemonic))
 	if len(words) == 0 {
 		return nil, errors.New("empty mnemonic")
 	}
 
-	dataInt := big.NewInt(0)
-	base := big.NewInt(int64(len(me.wordList)))
-
-	for _, word := range words {
+	indices := make([]int, len(words))
+	for i, word := range words {
 		wordIndex, exists := me.wordMap[word]
 		if !exists {
-			return nil, fmt.Errorf("unknown word: %s", word)
+			return nil, &UnknownWordError{Position: i, Word: word, Suggestions: me.SuggestWords(word, maxSuggestions)}
 		}
+		indices[i] = wordIndex
+	}
 
-		dataInt.Mul(dataInt, base)
-		dataInt.Add(dataInt, big.NewInt(int64(wordIndex)))
+	if me.encodingMode == EncodingPacked {
+		return me.decodePacked(indices)
 	}
 
-	return dataInt.Bytes(), nil
+	if wordBits, err := me.wordBits(); err == nil {
+		return decodeRadix2(indices, wordBits), nil
+	}
+	return decodeBaseN(indices, len(me.wordList)), nil
 }
 
+// AddChecksum appends the checksum words of the encoder's checksum version.
 func (me *MnemonicEncoder) AddChecksum(mnemonic string) string {
 	if mnemonic == "" {
 		return ""
 	}
 
-	words := strings.Fields(mnemonic)
+	words := strings.Fields(normalizeMnemonic(mnemonic))
+
+	// A single-word list cannot carry a multi-word checksum
+	var checksumWords []string
+	if me.checksumVersion == ChecksumLegacy || len(me.wordList) < 2 {
+		checksumWords = me.legacyChecksum(words)
+	} else {
+		checksumWords = me.sha256Checksum(words)
+	}
+
+	return mnemonic + me.separator + strings.Join(checksumWords, me.separator)
+}
+
+// VerifyChecksum checks the trailing checksum words of the encoder's
+// checksum version and returns the phrase without them.
+func (me *MnemonicEncoder) VerifyChecksum(mnemonicWithChecksum string) (string, bool) {
+	return me.VerifyChecksumVersion(mnemonicWithChecksum, me.checksumVersion)
+}
+
+// VerifyChecksumVersion is VerifyChecksum for the given checksum version. A
+// phrase is only ever checked against the version its reader asks for, so a
+// corrupted SHA-256 phrase cannot pass the weaker legacy check.
+func (me *MnemonicEncoder) VerifyChecksumVersion(mnemonicWithChecksum string, version ChecksumVersion) (string, bool) {
+	words := strings.Fields(normalizeMnemonic(mnemonicWithChecksum))
+	if len(me.wordList) == 0 {
+		return "", false
+	}
+
+	var count int
+	var checksum func([]string) []string
+	switch {
+	case version == ChecksumLegacy || (version == ChecksumSHA256 && len(me.wordList) < 2):
+		count, checksum = 1, me.legacyChecksum
+	case version == ChecksumSHA256:
+		count, checksum = me.checksumWordCount(), me.sha256Checksum
+	default:
+		return "", false
+	}
+
+	if len(words) <= count {
+		return "", false
+	}
+	body := words[:len(words)-count]
+	if !equalWords(words[len(words)-count:], checksum(body)) {
+		return "", false
+	}
+
+	return strings.Join(body, me.separator), true
+}
+
+func (me *MnemonicEncoder) legacyChecksum(words []string) []string {
 	checksum := 0
 
 	for _, word := range words {
@@ -97,25 +255,69 @@ func (me *MnemonicEncoder) AddChecksum(mnemonic string) string {
 		}
 	}
 
-	checksumWord := me.wordList[checksum%len(me.wordList)]
-	return mnemonic + " " + checksumWord
+	return []string{me.wordList[checksum%len(me.wordList)]}
 }
 
-func (me *MnemonicEncoder) VerifyChecksum(mnemonicWithChecksum string) (string, bool) {
-	words := strings.Fields(mnemonicWithChecksum)
-	if len(words) < 2 {
-		return "", false
+// checksumWordCount is the number of words needed to carry checksumBits.
+func (me *MnemonicEncoder) checksumWordCount() int {
+	if len(me.wordList) < 2 {
+		return 1
 	}
 
-	mnemonic := strings.Join(words[:len(words)-1], " ")
-	checksumWord := words[len(words)-1]
+	count := 1
+	for capacity := uint64(len(me.wordList)); capacity < 1<<checksumBits; capacity *= uint64(len(me.wordList)) {
+		count++
+	}
+	return count
+}
 
-	expectedChecksum := me.AddChecksum(mnemonic)
-	expectedWords := strings.Fields(expectedChecksum)
-	if len(expectedWords) == 0 {
-		return "", false
+// sha256Checksum hashes the word indices, unknown words counting as the
+// out-of-range index len(wordList), and writes the digest in base
+// len(wordList) over checksumWordCount words.
+func (me *MnemonicEncoder) sha256Checksum(words []string) []string {
+	hash := sha256.New()
+	hash.Write([]byte(checksumDomain))
+	hash.Write(binary.BigEndian.AppendUint32(nil, uint32(len(me.wordList))))
+	for _, word := range words {
+		index, exists := me.wordMap[word]
+		if !exists {
+			index = len(me.wordList)
+		}
+		hash.Write(binary.BigEndian.AppendUint32(nil, uint32(index)))
+	}
+	digest := hash.Sum(nil)
+
+	count := me.checksumWordCount()
+	base := uint64(len(me.wordList))
+	value := binary.BigEndian.Uint64(digest[:8])
+
+	checksumWords := make([]string, count)
+	for i := count - 1; i >= 0; i-- {
+		checksumWords[i] = me.wordList[value%base]
+		value /= base
 	}
-	expectedChecksumWord := expectedWords[len(expectedWords)-1]
 
-	return mnemonic, checksumWord == expectedChecksumWord
+	return checksumWords
+}
+
+// containsAll reports whether every word is in the encoder's list.
+func (me *MnemonicEncoder) containsAll(words []string) bool {
+	for _, word := range words {
+		if _, exists := me.wordMap[word]; !exists {
+			return false
+		}
+	}
+	return true
+}
+
+func equalWords(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
 }
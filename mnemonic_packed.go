@@ -0,0 +1,201 @@
+package pvss
+
+import (
+	"errors"
+	"fmt"
+	"math/bits"
+	"strings"
+)
+
+// EncodingMode selects how MnemonicEncoder turns bytes into words.
+type EncodingMode int
+
+const (
+	// EncodingBigInt reads the payload as one big-endian integer and writes
+	// it in base len(wordList). Leading zero bytes do not survive the round
+	// trip, and the word count depends on the value as well as the length.
+	EncodingBigInt EncodingMode = iota
+	// EncodingPacked splits the payload into fixed groups of log2(len(wordList))
+	// bits, 11 with a BIP-39 list, most significant bit first. A single 1 bit
+	// followed by zeros pads the last word, so decoding returns exactly the
+	// input bytes and a payload of n bytes always takes ceil((8n+1)/11) words.
+	// It requires a word list whose length is a power of two.
+	EncodingPacked
+)
+
+func (mode EncodingMode) String() string {
+	switch mode {
+	case EncodingBigInt:
+		return "bigint"
+	case EncodingPacked:
+		return "packed"
+	default:
+		return fmt.Sprintf("EncodingMode(%d)", int(mode))
+	}
+}
+
+// WithEncodingMode selects the byte-to-word encoding. The default is
+// EncodingBigInt, the format existing shares were written in.
+func WithEncodingMode(mode EncodingMode) MnemonicOption {
+	return func(me *MnemonicEncoder) {
+		me.encodingMode = mode
+	}
+}
+
+// withEncodingMode returns me, or a copy of it sharing the word list, that
+// uses the given mode.
+func (me *MnemonicEncoder) withEncodingMode(mode EncodingMode) *MnemonicEncoder {
+	if me.encodingMode == mode {
+		return me
+	}
+
+	encoder := *me
+	encoder.encodingMode = mode
+	return &encoder
+}
+
+// wordBits returns the bits carried by one word, or an error unless the
+// word list length is a power of two.
+func (me *MnemonicEncoder) wordBits() (int, error) {
+	n := len(me.wordList)
+	if n < 2 || n&(n-1) != 0 {
+		return 0, fmt.Errorf("packed encoding needs a power-of-two word list, got %d words", n)
+	}
+	return bits.TrailingZeros(uint(n)), nil
+}
+
+// PackedWordCount returns the number of words EncodingPacked writes for a
+// payload of dataLen bytes.
+func (me *MnemonicEncoder) PackedWordCount(dataLen int) (int, error) {
+	wordBits, err := me.wordBits()
+	if err != nil {
+		return 0, err
+	}
+	return (dataLen*8 + 1 + wordBits - 1) / wordBits, nil
+}
+
+func (me *MnemonicEncoder) encodePacked(data []byte) (string, error) {
+	wordBits, err := me.wordBits()
+	if err != nil {
+		return "", err
+	}
+
+	count, _ := me.PackedWordCount(len(data))
+	words := make([]string, 0, count)
+	mask := uint(1)<<wordBits - 1
+
+	var acc uint
+	var accBits int
+	for _, b := range data {
+		acc = acc<<8 | uint(b)
+		accBits += 8
+		for accBits >= wordBits {
+			accBits -= wordBits
+			words = append(words, me.wordList[(acc>>accBits)&mask])
+		}
+	}
+
+	// Fewer than wordBits bits remain: terminate them with a 1 bit and fill
+	// the last word with zeros
+	acc = acc<<1 | 1
+	accBits++
+	words = append(words, me.wordList[(acc<<(wordBits-accBits))&mask])
+
+	return strings.Join(words, me.separator), nil
+}
+
+func (me *MnemonicEncoder) decodePacked(indices []int) ([]byte, error) {
+	wordBits, err := me.wordBits()
+	if err != nil {
+		return nil, err
+	}
+
+	// The padding is confined to the last word: a 1 bit, then zeros
+	last := uint(indices[len(indices)-1])
+	if last == 0 {
+		return nil, errors.New("invalid packed padding")
+	}
+	padBits := bits.TrailingZeros(last) + 1
+
+	dataBits := len(indices)*wordBits - padBits
+	if dataBits%8 != 0 {
+		return nil, errors.New("invalid packed padding")
+	}
+
+	data := make([]byte, 0, dataBits/8)
+	var acc uint
+	var accBits int
+	for _, index := range indices {
+		acc = acc<<wordBits | uint(index)
+		accBits += wordBits
+		for accBits >= 8 && len(data) < dataBits/8 {
+			accBits -= 8
+			data = append(data, byte(acc>>accBits))
+		}
+	}
+
+	return data, nil
+}
+
+// encodeRadix2 produces the same digits as encodeBaseN for a base of
+// 2^wordBits, in linear time: it cuts wordBits-bit groups from the least
+// significant end of data and drops leading zero digits.
+func encodeRadix2(data []byte, wordBits int) []int {
+	indices := make([]int, (len(data)*8+wordBits-1)/wordBits)
+	mask := uint(1)<<wordBits - 1
+
+	pos := len(indices)
+	var acc uint
+	var accBits int
+	for i := len(data) - 1; i >= 0; i-- {
+		acc |= uint(data[i]) << accBits
+		accBits += 8
+		for accBits >= wordBits {
+			pos--
+			indices[pos] = int(acc & mask)
+			acc >>= wordBits
+			accBits -= wordBits
+		}
+	}
+	if accBits > 0 {
+		pos--
+		indices[pos] = int(acc & mask)
+	}
+
+	for len(indices) > 1 && indices[0] == 0 {
+		indices = indices[1:]
+	}
+
+	return indices
+}
+
+// decodeRadix2 is the linear-time inverse of encodeRadix2, returning the
+// same minimal big-endian bytes as decodeBaseN.
+func decodeRadix2(indices []int, wordBits int) []byte {
+	data := make([]byte, (len(indices)*wordBits+7)/8)
+
+	pos := len(data)
+	var acc uint
+	var accBits int
+	for i := len(indices) - 1; i >= 0; i-- {
+		acc |= uint(indices[i]) << accBits
+		accBits += wordBits
+		for accBits >= 8 {
+			pos--
+			data[pos] = byte(acc)
+			acc >>= 8
+			accBits -= 8
+		}
+	}
+	if accBits > 0 {
+		pos--
+		data[pos] = byte(acc)
+	}
+
+	start := 0
+	for start < len(data) && data[start] == 0 {
+		start++
+	}
+
+	return data[start:]
+}
@@ -0,0 +1,234 @@
+package pvss
+
+import (
+	"bytes"
+	"crypto/rand"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// TestPackedEncoding_RoundTrip tests that every payload comes back exactly,
+// leading zeros included, with a word count fixed by its length
+func TestPackedEncoding_RoundTrip(t *testing.T) {
+	encoder := NewMnemonicEncoder(BIP39EnglishWords(), WithEncodingMode(EncodingPacked))
+
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"single zero byte", []byte{0}},
+		{"single leading zero", []byte{0, 1, 2, 3}},
+		{"multiple leading zeros", []byte{0, 0, 0, 1, 2, 3}},
+		{"all zeros", []byte{0, 0, 0, 0}},
+		{"all ones", bytes.Repeat([]byte{0xff}, 33)},
+		{"word aligned", bytes.Repeat([]byte{0xa5}, 11)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mnemonic, err := encoder.EncodeToMnemonic(tt.data)
+			if err != nil {
+				t.Fatalf("EncodeToMnemonic failed: %v", err)
+			}
+
+			expectedWords, _ := encoder.PackedWordCount(len(tt.data))
+			if got := len(strings.Fields(mnemonic)); got != expectedWords {
+				t.Errorf("expected %d words, got %d", expectedWords, got)
+			}
+
+			decoded, err := encoder.DecodeFromMnemonic(mnemonic)
+			if err != nil {
+				t.Fatalf("DecodeFromMnemonic failed: %v", err)
+			}
+			if !bytes.Equal(decoded, tt.data) {
+				t.Errorf("expected %x, got %x", tt.data, decoded)
+			}
+		})
+	}
+}
+
+// TestPackedEncoding_AllLengths tests random payloads of every length up to
+// a few words past several alignment boundaries
+func TestPackedEncoding_AllLengths(t *testing.T) {
+	encoder := NewMnemonicEncoder(BIP39EnglishWords(), WithEncodingMode(EncodingPacked))
+
+	for length := 1; length <= 70; length++ {
+		data := make([]byte, length)
+		rand.Read(data)
+		data[0] = 0
+
+		mnemonic, err := encoder.EncodeToMnemonic(data)
+		if err != nil {
+			t.Fatalf("length %d: EncodeToMnemonic failed: %v", length, err)
+		}
+
+		decoded, err := encoder.DecodeFromMnemonic(mnemonic)
+		if err != nil {
+			t.Fatalf("length %d: DecodeFromMnemonic failed: %v", length, err)
+		}
+		if !bytes.Equal(decoded, data) {
+			t.Errorf("length %d: expected %x, got %x", length, data, decoded)
+		}
+
+		if expected := (length*8 + 11) / 11; len(strings.Fields(mnemonic)) != expected {
+			t.Errorf("length %d: expected %d words, got %d", length, expected, len(strings.Fields(mnemonic)))
+		}
+	}
+}
+
+// TestPackedEncoding_KnownVector tests the bit layout against a hand-packed value
+func TestPackedEncoding_KnownVector(t *testing.T) {
+	encoder := NewMnemonicEncoder(BIP39EnglishWords(), WithEncodingMode(EncodingPacked))
+
+	// 0x00 0x01 → 00000000 00000001 1 00000: indices 0 (abandon) and 0b01100000 = 96
+	mnemonic, err := encoder.EncodeToMnemonic([]byte{0x00, 0x01})
+	if err != nil {
+		t.Fatalf("EncodeToMnemonic failed: %v", err)
+	}
+
+	expected := "abandon " + BIP39EnglishWords()[96]
+	if mnemonic != expected {
+		t.Errorf("expected %q, got %q", expected, mnemonic)
+	}
+}
+
+// TestPackedEncoding_InvalidPadding tests that phrases not ending in valid
+// padding are rejected
+func TestPackedEncoding_InvalidPadding(t *testing.T) {
+	encoder := NewMnemonicEncoder(BIP39EnglishWords(), WithEncodingMode(EncodingPacked))
+
+	tests := []string{
+		"abandon abandon", // No terminating 1 bit
+		"abandon zoo",     // Data bits not a whole number of bytes
+	}
+
+	for _, mnemonic := range tests {
+		if _, err := encoder.DecodeFromMnemonic(mnemonic); err == nil {
+			t.Errorf("expected padding error for %q", mnemonic)
+		}
+	}
+}
+
+// TestPackedEncoding_RequiresPowerOfTwo tests the word list requirement
+func TestPackedEncoding_RequiresPowerOfTwo(t *testing.T) {
+	encoder := NewMnemonicEncoder(getTestWordList(), WithEncodingMode(EncodingPacked))
+
+	if _, err := encoder.EncodeToMnemonic([]byte{1, 2, 3}); err == nil {
+		t.Error("expected error for a 10-word list")
+	}
+}
+
+// TestPackedEncoding_Shares tests splitting and reconstructing with a
+// packed encoder
+func TestPackedEncoding_Shares(t *testing.T) {
+	encoder := NewMnemonicEncoder(BIP39EnglishWords(), WithEncodingMode(EncodingPacked))
+	pvss := NewPedersenVSS(WithMnemonicEncoder(encoder))
+
+	secret := []byte{0, 0, 7, 0}
+	shares, err := pvss.SplitBytes(secret, 3, 2)
+	if err != nil {
+		t.Fatalf("SplitBytes failed: %v", err)
+	}
+
+	for i, share := range shares {
+		if valid, err := pvss.VerifyShare(share); err != nil || !valid {
+			t.Errorf("share %d failed verification: %v", i, err)
+		}
+	}
+
+	reconstructed, err := pvss.ReconstructBytes(shares[1:])
+	if err != nil {
+		t.Fatalf("ReconstructBytes failed: %v", err)
+	}
+	if !bytes.Equal(reconstructed, secret) {
+		t.Errorf("expected %x, got %x", secret, reconstructed)
+	}
+}
+
+// TestPackedEncoding_ModeRecorded tests that shares record their encoding
+// mode, so any instance reads them, including after translation
+func TestPackedEncoding_ModeRecorded(t *testing.T) {
+	packed := NewPedersenVSS(WithMnemonicEncoder(NewMnemonicEncoder(BIP39EnglishWords(), WithEncodingMode(EncodingPacked))))
+	bigInt := NewPedersenVSS()
+	secret := []byte("written in one mode, read in the other")
+
+	for _, tt := range []struct {
+		name           string
+		writer, reader *PedersenVSS
+	}{
+		{"packed read by default", packed, bigInt},
+		{"default read by packed", bigInt, packed},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			shares, err := tt.writer.SplitBytes(secret, 3, 2)
+			if err != nil {
+				t.Fatalf("SplitBytes failed: %v", err)
+			}
+
+			keyBytes, err := tt.writer.decodePayload(tt.writer.mnemonicEncoder, mustStripChecksum(t, tt.writer, shares[0].Key), shareMagic)
+			if err != nil {
+				t.Fatalf("decodePayload failed: %v", err)
+			}
+			if flagged := keyBytes[4]&flagPacked != 0; flagged != (tt.writer == packed) {
+				t.Errorf("header flags %#02x", keyBytes[4])
+			}
+
+			translated, err := TranslateShare(shares[1], LanguageJapanese)
+			if err != nil {
+				t.Fatalf("TranslateShare failed: %v", err)
+			}
+
+			for i, share := range []Share{shares[0], translated} {
+				if valid, err := tt.reader.VerifyShare(share); err != nil || !valid {
+					t.Errorf("share %d failed verification: %v", i, err)
+				}
+			}
+
+			reconstructed, err := tt.reader.ReconstructBytes([]Share{shares[0], translated})
+			if err != nil {
+				t.Fatalf("ReconstructBytes failed: %v", err)
+			}
+			if !bytes.Equal(reconstructed, secret) {
+				t.Errorf("expected %q, got %q", secret, reconstructed)
+			}
+		})
+	}
+}
+
+// TestRadix2_MatchesBigInt tests that the linear encoder writes exactly the
+// words the big-integer conversion does, for every power-of-two list size
+func TestRadix2_MatchesBigInt(t *testing.T) {
+	payloads := [][]byte{
+		{0},
+		{0, 0, 0},
+		{1},
+		{0, 0, 1, 0},
+		bytes.Repeat([]byte{0xff}, 33),
+	}
+	for length := 1; length <= 80; length++ {
+		data := make([]byte, length)
+		rand.Read(data)
+		payloads = append(payloads, data)
+	}
+
+	for _, wordBits := range []int{1, 3, 8, 10, 11, 16} {
+		for _, data := range payloads {
+			expected := encodeBaseN(data, 1<<wordBits)
+			indices := encodeRadix2(data, wordBits)
+			if !reflect.DeepEqual(indices, expected) {
+				t.Fatalf("%d bits, data %x: expected %v, got %v", wordBits, data, expected, indices)
+			}
+
+			// Leading zero words are ignored, as by the big-integer decoder
+			padded := append([]int{0, 0}, indices...)
+			decoded := decodeRadix2(padded, wordBits)
+			if !bytes.Equal(decoded, decodeBaseN(padded, 1<<wordBits)) {
+				t.Fatalf("%d bits, data %x: decoders disagree", wordBits, data)
+			}
+			if !bytes.Equal(decoded, bytes.TrimLeft(data, "\x00")) {
+				t.Fatalf("%d bits: expected %x, got %x", wordBits, bytes.TrimLeft(data, "\x00"), decoded)
+			}
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package pvss
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ErrMnemonicUnrecoverable is returned by RepairMnemonic when the phrase has
+// more damage than it can undo unambiguously.
+var ErrMnemonicUnrecoverable = errors.New("mnemonic could not be repaired")
+
+const (
+	// bip39PrefixLength letters identify a BIP-39 word uniquely.
+	bip39PrefixLength = 4
+	maxSuggestions    = 5
+	// maxRepairCandidates bounds the fixes RepairMnemonic tries, so the
+	// chance that a wrong one satisfies the checksum stays below 2^-10.
+	maxRepairCandidates = 1 << (checksumBits - 10)
+)
+
+// UnknownWordError reports a word that is not in the word list, together
+// with the closest words that are.
+type UnknownWordError struct {
+	Position    int
+	Word        string
+	Suggestions []string
+}
+
+func (e *UnknownWordError) Error() string {
+	return fmt.Sprintf("unknown word: %s", e.Word)
+}
+
+// WordCorrection describes one word RepairMnemonic changed or could not fix.
+// Corrected is empty when the word is left unresolved, in which case
+// Candidates lists the closest words.
+type WordCorrection struct {
+	Position   int
+	Original   string
+	Corrected  string
+	Candidates []string
+}
+
+// LookupWord resolves a word as written on a paper backup: case is ignored
+// and, as in BIP-39, any prefix of at least four letters that begins exactly
+// one word stands for that word.
+func (me *MnemonicEncoder) LookupWord(word string) (string, bool) {
+	word = strings.ToLower(strings.TrimSpace(normalizeMnemonic(word)))
+	if _, exists := me.wordMap[word]; exists {
+		return word, true
+	}
+
+	if len([]rune(word)) < bip39PrefixLength {
+		return "", false
+	}
+
+	match := ""
+	for _, candidate := range me.wordList {
+		if strings.HasPrefix(candidate, word) {
+			if match != "" {
+				return "", false
+			}
+			match = candidate
+		}
+	}
+
+	return match, match != ""
+}
+
+// SuggestWords returns up to limit words from the list closest to word by
+// edit distance, counting a swap of adjacent letters as one edit. Ties are
+// broken in favour of words sharing a longer prefix, then list order.
+func (me *MnemonicEncoder) SuggestWords(word string, limit int) []string {
+	word = strings.ToLower(strings.TrimSpace(normalizeMnemonic(word)))
+
+	type scored struct {
+		index    int
+		distance int
+		prefix   int
+	}
+
+	candidates := make([]scored, len(me.wordList))
+	for i, candidate := range me.wordList {
+		candidates[i] = scored{
+			index:    i,
+			distance: editDistance(word, candidate),
+			prefix:   commonPrefixLength(word, candidate),
+		}
+	}
+
+	sort.SliceStable(candidates, func(a, b int) bool {
+		if candidates[a].distance != candidates[b].distance {
+			return candidates[a].distance < candidates[b].distance
+		}
+		return candidates[a].prefix > candidates[b].prefix
+	})
+
+	if limit > len(candidates) {
+		limit = len(candidates)
+	}
+
+	suggestions := make([]string, 0, limit)
+	for _, candidate := range candidates[:limit] {
+		suggestions = append(suggestions, me.wordList[candidate.index])
+	}
+
+	return suggestions
+}
+
+// RepairMnemonic restores a Key or KeyCheck phrase copied by hand.
+// Abbreviated and mis-capitalised words are expanded first. If the phrase
+// then fails its checksum, a single unknown word or one swapped pair of
+// neighbouring words is corrected automatically, provided the phrase carries
+// a ChecksumSHA256 checksum and exactly one fix satisfies it and decodes to a
+// well-formed share payload. A wrong but valid word is only searched for in
+// phrases short enough that trying every replacement stays within
+// maxRepairCandidates, which no share phrase is. The legacy checksum is too
+// weak to pick a fix, so such phrases are only expanded. The returned
+// corrections list every word that was changed, or that could not be
+// resolved together with suggestions for it; in the latter case the error
+// wraps ErrMnemonicUnrecoverable.
+func (me *MnemonicEncoder) RepairMnemonic(mnemonic string) (string, []WordCorrection, error) {
+	words := strings.Fields(mnemonic)
+	if len(words) == 0 {
+		return "", nil, errors.New("empty mnemonic")
+	}
+
+	var corrections []WordCorrection
+	var unknown []int
+
+	for i, word := range words {
+		resolved, ok := me.LookupWord(word)
+		if !ok {
+			unknown = append(unknown, i)
+			continue
+		}
+		if resolved != word {
+			corrections = append(corrections, WordCorrection{Position: i, Original: word, Corrected: resolved})
+			words[i] = resolved
+		}
+	}
+
+	if len(unknown) == 0 {
+		if _, valid := me.VerifyChecksum(strings.Join(words, " ")); valid {
+			return strings.Join(words, me.separator), corrections, nil
+		}
+	}
+
+	// Each repair is a set of word changes; only a unique one is applied
+	var repairs [][]WordCorrection
+	readers := make(repairReaders)
+	switch len(unknown) {
+	case 0:
+		if me.substitutionCount(len(words)) <= maxRepairCandidates {
+			repairs = me.findSubstitutions(words, nil, readers)
+		}
+		repairs = append(repairs, me.findTranspositions(words, readers)...)
+	case 1:
+		if me.substitutionCount(1) <= maxRepairCandidates {
+			repairs = me.findSubstitutions(words, unknown, readers)
+		}
+	}
+
+	if len(repairs) == 1 {
+		for _, fix := range repairs[0] {
+			words[fix.Position] = fix.Corrected
+		}
+		corrections = append(corrections, repairs[0]...)
+		sortCorrections(corrections)
+		return strings.Join(words, me.separator), corrections, nil
+	}
+
+	for _, i := range unknown {
+		corrections = append(corrections, WordCorrection{
+			Position:   i,
+			Original:   words[i],
+			Candidates: me.SuggestWords(words[i], maxSuggestions),
+		})
+	}
+	sortCorrections(corrections)
+
+	if len(unknown) > 0 {
+		return "", corrections, fmt.Errorf("%w: %d unknown word(s)", ErrMnemonicUnrecoverable, len(unknown))
+	}
+	return "", corrections, fmt.Errorf("%w: checksum mismatch", ErrMnemonicUnrecoverable)
+}
+
+// substitutionCount is the number of candidates findSubstitutions tries
+// over the given number of positions.
+func (me *MnemonicEncoder) substitutionCount(positions int) int {
+	return positions * (len(me.wordList) - 1)
+}
+
+// repairReaders holds the PedersenVSS that parses candidate repairs over
+// each group, keyed by curve ID. Each is built once per repair, as deriving
+// its blinding generator is costly.
+type repairReaders map[byte]*PedersenVSS
+
+// validRepair reports whether words carry a ChecksumSHA256 checksum and
+// decode to a Key or KeyCheck payload with a known header whose body parses.
+func (me *MnemonicEncoder) validRepair(words []string, readers repairReaders) bool {
+	body, valid := me.VerifyChecksumVersion(strings.Join(words, " "), ChecksumSHA256)
+	if !valid {
+		return false
+	}
+
+	data, err := me.DecodeFromMnemonic(body)
+	if err != nil || len(data) < headerSize {
+		return false
+	}
+	group := groupByID(data[2])
+	if group == nil || !headerRecordsMode(data, data[0], group.ID(), me.encodingMode) {
+		return false
+	}
+
+	pvss, ok := readers[group.ID()]
+	if !ok {
+		pvss = NewPedersenVSS(WithGroup(group), WithMnemonicEncoder(me))
+		readers[group.ID()] = pvss
+	}
+	switch data[0] {
+	case shareMagic:
+		_, err = pvss.deserializeShareData(data)
+	case metadataMagic:
+		_, err = pvss.deserializeMetadata(data)
+	default:
+		return false
+	}
+	return err == nil
+}
+
+// findSubstitutions tries every list word at each of the given positions,
+// or at every position if none are given, and returns the replacements that
+// pass validRepair. It stops after two, which is already ambiguous.
+func (me *MnemonicEncoder) findSubstitutions(words []string, positions []int, readers repairReaders) [][]WordCorrection {
+	if positions == nil {
+		positions = make([]int, len(words))
+		for i := range positions {
+			positions[i] = i
+		}
+	}
+
+	var found [][]WordCorrection
+	candidate := append([]string{}, words...)
+
+	for _, i := range positions {
+		for _, replacement := range me.wordList {
+			if replacement == words[i] {
+				continue
+			}
+
+			candidate[i] = replacement
+			if me.validRepair(candidate, readers) {
+				found = append(found, []WordCorrection{{Position: i, Original: words[i], Corrected: replacement}})
+				if len(found) > 1 {
+					return found
+				}
+			}
+		}
+		candidate[i] = words[i]
+	}
+
+	return found
+}
+
+// findTranspositions returns the swaps of neighbouring words that pass
+// validRepair.
+func (me *MnemonicEncoder) findTranspositions(words []string, readers repairReaders) [][]WordCorrection {
+	var found [][]WordCorrection
+	candidate := append([]string{}, words...)
+
+	for i := 0; i+1 < len(words); i++ {
+		if words[i] == words[i+1] {
+			continue
+		}
+
+		candidate[i], candidate[i+1] = words[i+1], words[i]
+		if me.validRepair(candidate, readers) {
+			found = append(found, []WordCorrection{
+				{Position: i, Original: words[i], Corrected: words[i+1]},
+				{Position: i + 1, Original: words[i+1], Corrected: words[i]},
+			})
+		}
+		candidate[i], candidate[i+1] = words[i], words[i+1]
+	}
+
+	return found
+}
+
+func sortCorrections(corrections []WordCorrection) {
+	sort.Slice(corrections, func(a, b int) bool { return corrections[a].Position < corrections[b].Position })
+}
+
+// editDistance is the optimal string alignment distance between a and b:
+// insertions, deletions, substitutions and swaps of adjacent letters.
+func editDistance(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+
+	prev2 := make([]int, len(rb)+1)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+			if i > 1 && j > 1 && ra[i-1] == rb[j-2] && ra[i-2] == rb[j-1] {
+				curr[j] = min(curr[j], prev2[j-2]+1)
+			}
+		}
+		prev2, prev, curr = prev, curr, prev2
+	}
+
+	return prev[len(rb)]
+}
+
+func commonPrefixLength(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	n := 0
+	for n < len(ra) && n < len(rb) && ra[n] == rb[n] {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,240 @@
+package pvss
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// repairTestPhrase returns the words of a share Key, the kind of phrase
+// RepairMnemonic is meant for.
+func repairTestPhrase(t *testing.T, encoder *MnemonicEncoder) []string {
+	t.Helper()
+
+	shares, err := NewPedersenVSS(WithMnemonicEncoder(encoder)).SplitSecret("paper backup restore flow", 3, 2)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+	return strings.Fields(shares[0].Key)
+}
+
+// TestLookupWord tests case folding and unique-prefix expansion
+func TestLookupWord(t *testing.T) {
+	encoder := NewMnemonicEncoder(BIP39EnglishWords())
+
+	tests := []struct {
+		word     string
+		expected string
+		ok       bool
+	}{
+		{"abandon", "abandon", true},
+		{"ABANDON", "abandon", true},
+		{"aban", "abandon", true},
+		{"abando", "abandon", true},
+		{"zoo", "zoo", true},
+		{"aba", "", false}, // Too short to abbreviate
+		{"abst", "abstract", true},
+		{"abso", "absorb", true}, // Four letters are always unique
+		{"xyzzy", "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			word, ok := encoder.LookupWord(tt.word)
+			if ok != tt.ok || word != tt.expected {
+				t.Errorf("LookupWord(%q) = (%q, %v), expected (%q, %v)", tt.word, word, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+// TestSuggestWords tests that the closest words come first
+func TestSuggestWords(t *testing.T) {
+	encoder := NewMnemonicEncoder(BIP39EnglishWords())
+
+	tests := []struct {
+		word     string
+		expected string
+	}{
+		{"abandno", "abandon"},  // Swapped letters
+		{"abandn", "abandon"},   // Dropped letter
+		{"abanndon", "abandon"}, // Doubled letter
+		{"zebro", "zebra"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.word, func(t *testing.T) {
+			suggestions := encoder.SuggestWords(tt.word, 3)
+			if len(suggestions) != 3 {
+				t.Fatalf("expected 3 suggestions, got %v", suggestions)
+			}
+			if suggestions[0] != tt.expected {
+				t.Errorf("expected %q first, got %v", tt.expected, suggestions)
+			}
+		})
+	}
+}
+
+// TestDecodeFromMnemonic_UnknownWordSuggestions tests the decode error detail
+func TestDecodeFromMnemonic_UnknownWordSuggestions(t *testing.T) {
+	encoder := NewMnemonicEncoder(BIP39EnglishWords())
+
+	_, err := encoder.DecodeFromMnemonic("abandon abilty able")
+
+	var unknown *UnknownWordError
+	if !errors.As(err, &unknown) {
+		t.Fatalf("expected UnknownWordError, got %v", err)
+	}
+	if unknown.Position != 1 || unknown.Word != "abilty" {
+		t.Errorf("unexpected error detail: %+v", unknown)
+	}
+	if len(unknown.Suggestions) == 0 || unknown.Suggestions[0] != "ability" {
+		t.Errorf("expected 'ability' suggested first, got %v", unknown.Suggestions)
+	}
+}
+
+// TestRepairMnemonic tests the automatic corrections
+func TestRepairMnemonic(t *testing.T) {
+	encoder := NewMnemonicEncoder(BIP39EnglishWords())
+	words := repairTestPhrase(t, encoder)
+	original := strings.Join(words, " ")
+
+	damage := map[string]func([]string) []string{
+		"abbreviated": func(w []string) []string {
+			for i := range w {
+				if len(w[i]) > bip39PrefixLength {
+					w[i] = strings.ToUpper(w[i][:bip39PrefixLength])
+				}
+			}
+			return w
+		},
+		"misspelled": func(w []string) []string {
+			w[3] = w[3][:len(w[3])-1] + "q"
+			return w
+		},
+		"swapped words": func(w []string) []string {
+			w[2], w[3] = w[3], w[2]
+			return w
+		},
+	}
+
+	for name, corrupt := range damage {
+		t.Run(name, func(t *testing.T) {
+			damaged := strings.Join(corrupt(append([]string{}, words...)), " ")
+			if damaged == original {
+				t.Skip("damage left the phrase unchanged")
+			}
+
+			repaired, corrections, err := encoder.RepairMnemonic(damaged)
+			if err != nil {
+				t.Fatalf("RepairMnemonic failed: %v (%+v)", err, corrections)
+			}
+			if repaired != original {
+				t.Errorf("expected %q, got %q", original, repaired)
+			}
+			if len(corrections) == 0 {
+				t.Error("expected corrections to be reported")
+			}
+		})
+	}
+}
+
+// TestRepairMnemonic_Unrecoverable tests that ambiguous damage is reported
+// with suggestions rather than guessed
+func TestRepairMnemonic_Unrecoverable(t *testing.T) {
+	encoder := NewMnemonicEncoder(BIP39EnglishWords())
+	words := repairTestPhrase(t, encoder)
+
+	words[1] = "qqqqq"
+	words[4] = "xxxxx"
+
+	_, corrections, err := encoder.RepairMnemonic(strings.Join(words, " "))
+	if !errors.Is(err, ErrMnemonicUnrecoverable) {
+		t.Fatalf("expected ErrMnemonicUnrecoverable, got %v", err)
+	}
+
+	if len(corrections) != 2 {
+		t.Fatalf("expected 2 unresolved words, got %+v", corrections)
+	}
+	for _, correction := range corrections {
+		if correction.Corrected != "" || len(correction.Candidates) != maxSuggestions {
+			t.Errorf("unexpected correction: %+v", correction)
+		}
+	}
+}
+
+// TestRepairMnemonic_WrongWordNotGuessed tests that a wrong but valid word
+// in a share phrase is not searched for, since with that many candidates a
+// wrong fix could satisfy the checksum
+func TestRepairMnemonic_WrongWordNotGuessed(t *testing.T) {
+	encoder := NewMnemonicEncoder(BIP39EnglishWords())
+
+	// A body word and a checksum word
+	for _, position := range []int{5, -1} {
+		words := repairTestPhrase(t, encoder)
+		if position < 0 {
+			position = len(words) - 1
+		}
+
+		if words[position] == "zoo" {
+			words[position] = "zone"
+		} else {
+			words[position] = "zoo"
+		}
+
+		if _, _, err := encoder.RepairMnemonic(strings.Join(words, " ")); !errors.Is(err, ErrMnemonicUnrecoverable) {
+			t.Errorf("word %d: expected ErrMnemonicUnrecoverable, got %v", position, err)
+		}
+	}
+}
+
+// TestRepairMnemonic_NotAShare tests that a fix satisfying the checksum is
+// still refused when the phrase does not decode to a share
+func TestRepairMnemonic_NotAShare(t *testing.T) {
+	encoder := NewMnemonicEncoder(BIP39EnglishWords())
+
+	mnemonic, err := encoder.EncodeToMnemonic([]byte("paper backup restore flow"))
+	if err != nil {
+		t.Fatalf("EncodeToMnemonic failed: %v", err)
+	}
+	words := strings.Fields(encoder.AddChecksum(mnemonic))
+	words[2], words[3] = words[3], words[2]
+
+	if _, _, err := encoder.RepairMnemonic(strings.Join(words, " ")); !errors.Is(err, ErrMnemonicUnrecoverable) {
+		t.Errorf("expected ErrMnemonicUnrecoverable, got %v", err)
+	}
+}
+
+// TestRepairMnemonic_LegacyChecksumNotGuessed tests that the weak checksum
+// is never used to pick a replacement word
+func TestRepairMnemonic_LegacyChecksumNotGuessed(t *testing.T) {
+	encoder := NewMnemonicEncoder(BIP39EnglishWords(), WithChecksumVersion(ChecksumLegacy))
+	words := repairTestPhrase(t, encoder)
+
+	words[2] = "qqqqq"
+
+	if _, _, err := encoder.RepairMnemonic(strings.Join(words, " ")); !errors.Is(err, ErrMnemonicUnrecoverable) {
+		t.Errorf("expected ErrMnemonicUnrecoverable, got %v", err)
+	}
+}
+
+// TestEditDistance tests the distance function
+func TestEditDistance(t *testing.T) {
+	tests := []struct {
+		a, b     string
+		expected int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"abandon", "abandon", 0},
+		{"abandon", "abandno", 1},
+		{"kitten", "sitting", 3},
+		{"ca", "abc", 3},
+	}
+
+	for _, tt := range tests {
+		if got := editDistance(tt.a, tt.b); got != tt.expected {
+			t.Errorf("editDistance(%q, %q) = %d, expected %d", tt.a, tt.b, got, tt.expected)
+		}
+	}
+}
@@ -3,6 +3,7 @@ package pvss
 import (
 	"bytes"
 	"crypto/rand"
+	"fmt"
 	"reflect"
 	"strings"
 	"testing"
@@ -389,12 +390,13 @@ func TestAddChecksum(t *testing.T) {
 				return
 			}
 
-			// Verify the result has one more word
+			// Verify the result has the checksum words appended
 			originalWords := strings.Fields(tt.mnemonic)
 			resultWords := strings.Fields(result)
+			checksumCount := encoder.checksumWordCount()
 
-			if len(resultWords) != len(originalWords)+1 {
-				t.Errorf("expected %d words, got %d", len(originalWords)+1, len(resultWords))
+			if len(resultWords) != len(originalWords)+checksumCount {
+				t.Errorf("expected %d words, got %d", len(originalWords)+checksumCount, len(resultWords))
 			}
 
 			// Verify the original mnemonic is preserved
@@ -403,10 +405,11 @@ func TestAddChecksum(t *testing.T) {
 				t.Errorf("original mnemonic not preserved: expected %q, got %q", tt.mnemonic, resultPrefix)
 			}
 
-			// Verify checksum word is from word list
-			checksumWord := resultWords[len(resultWords)-1]
-			if _, exists := encoder.wordMap[checksumWord]; !exists {
-				t.Errorf("checksum word %q not in word list", checksumWord)
+			// Verify checksum words are from word list
+			for _, checksumWord := range resultWords[len(originalWords):] {
+				if _, exists := encoder.wordMap[checksumWord]; !exists {
+					t.Errorf("checksum word %q not in word list", checksumWord)
+				}
 			}
 		})
 	}
@@ -538,6 +541,83 @@ func TestVerifyChecksum_WithInvalidChecksum(t *testing.T) {
 	}
 }
 
+// TestChecksum_DetectsTranspositions tests that swapping any two adjacent
+// distinct words or substituting a word invalidates the phrase
+func TestChecksum_DetectsTranspositions(t *testing.T) {
+	encoder := NewMnemonicEncoder(BIP39EnglishWords())
+
+	if count := encoder.checksumWordCount(); count != 2 {
+		t.Fatalf("expected 2 checksum words with the BIP-39 list, got %d", count)
+	}
+
+	original := "legal winner thank year wave sausage worth useful legal winner thank yellow"
+	words := strings.Fields(encoder.AddChecksum(original))
+
+	for i := 0; i+1 < len(words); i++ {
+		if words[i] == words[i+1] {
+			continue
+		}
+
+		swapped := append([]string{}, words...)
+		swapped[i], swapped[i+1] = swapped[i+1], swapped[i]
+		if _, valid := encoder.VerifyChecksum(strings.Join(swapped, " ")); valid {
+			t.Errorf("swap of words %d and %d not detected", i, i+1)
+		}
+
+		substituted := append([]string{}, words...)
+		substituted[i] = "zoo"
+		if _, valid := encoder.VerifyChecksum(strings.Join(substituted, " ")); valid {
+			t.Errorf("substitution of word %d not detected", i)
+		}
+	}
+}
+
+// TestChecksum_LegacyVersion tests that phrases written with the old
+// sum-of-indices checksum verify only when the reader asks for it
+func TestChecksum_LegacyVersion(t *testing.T) {
+	legacy := NewMnemonicEncoder(BIP39EnglishWords(), WithChecksumVersion(ChecksumLegacy))
+	current := NewMnemonicEncoder(BIP39EnglishWords())
+
+	original := "abandon ability able about above absent absorb"
+
+	legacyPhrase := legacy.AddChecksum(original)
+	if len(strings.Fields(legacyPhrase)) != len(strings.Fields(original))+1 {
+		t.Fatalf("legacy checksum should be one word: %q", legacyPhrase)
+	}
+
+	if extracted, valid := legacy.VerifyChecksum(legacyPhrase); !valid || extracted != original {
+		t.Errorf("legacy encoder: got (%q, %v)", extracted, valid)
+	}
+	if extracted, valid := current.VerifyChecksumVersion(legacyPhrase, ChecksumLegacy); !valid || extracted != original {
+		t.Errorf("explicit legacy version: got (%q, %v)", extracted, valid)
+	}
+	if _, valid := current.VerifyChecksum(legacyPhrase); valid {
+		t.Error("SHA-256 encoder accepted a legacy checksum")
+	}
+
+	// A damaged SHA-256 phrase never falls back to the legacy check, which
+	// accepts about one in 2048 substitutions
+	words := strings.Fields(current.AddChecksum(original))
+	for i := range words {
+		for _, replacement := range BIP39EnglishWords() {
+			if replacement == words[i] {
+				continue
+			}
+			substituted := append([]string{}, words...)
+			substituted[i] = replacement
+			if _, valid := current.VerifyChecksum(strings.Join(substituted, " ")); valid {
+				t.Fatalf("substitution of word %d with %q accepted", i, replacement)
+			}
+		}
+	}
+
+	// The legacy checksum is blind to word order, which is why it was replaced
+	swapped := "ability abandon able about above absent absorb"
+	if _, valid := legacy.VerifyChecksum(swapped + " " + strings.Fields(legacyPhrase)[7]); !valid {
+		t.Error("expected legacy checksum to ignore word order")
+	}
+}
+
 // TestChecksum_Deterministic tests that checksum is deterministic
 func TestChecksum_Deterministic(t *testing.T) {
 	encoder := NewMnemonicEncoder(getTestWordList())
@@ -728,6 +808,54 @@ func BenchmarkDecodeFromMnemonic(b *testing.B) {
 	}
 }
 
+// BenchmarkEncodeToMnemonic_Large benchmarks encoding a KeyCheck-sized
+// payload, roughly 120 commitments of 33 bytes
+func BenchmarkEncodeToMnemonic_Large(b *testing.B) {
+	for _, size := range []int{4096, 16384} {
+		data := make([]byte, size)
+		rand.Read(data)
+
+		b.Run(fmt.Sprintf("radix2/%d", size), func(b *testing.B) {
+			encoder := NewMnemonicEncoder(BIP39EnglishWords())
+			b.SetBytes(int64(size))
+			for i := 0; i < b.N; i++ {
+				_, _ = encoder.EncodeToMnemonic(data)
+			}
+		})
+
+		b.Run(fmt.Sprintf("bigint/%d", size), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			for i := 0; i < b.N; i++ {
+				_ = encodeBaseN(data, 2048)
+			}
+		})
+	}
+}
+
+// BenchmarkDecodeFromMnemonic_Large benchmarks decoding a KeyCheck-sized
+// payload
+func BenchmarkDecodeFromMnemonic_Large(b *testing.B) {
+	for _, size := range []int{4096, 16384} {
+		data := make([]byte, size)
+		rand.Read(data)
+		indices := encodeBaseN(data, 2048)
+
+		b.Run(fmt.Sprintf("radix2/%d", size), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			for i := 0; i < b.N; i++ {
+				_ = decodeRadix2(indices, 11)
+			}
+		})
+
+		b.Run(fmt.Sprintf("bigint/%d", size), func(b *testing.B) {
+			b.SetBytes(int64(size))
+			for i := 0; i < b.N; i++ {
+				_ = decodeBaseN(indices, 2048)
+			}
+		})
+	}
+}
+
 // BenchmarkAddChecksum benchmarks checksum addition
 func BenchmarkAddChecksum(b *testing.B) {
 	encoder := NewMnemonicEncoder(BIP39EnglishWords())
@@ -833,4 +961,24 @@ func TestLeadingZeros(t *testing.T) {
 			}
 		})
 	}
+
+	// The packed encoding keeps them
+	packed := NewMnemonicEncoder(BIP39EnglishWords(), WithEncodingMode(EncodingPacked))
+	for _, tt := range tests {
+		t.Run(tt.name+" packed", func(t *testing.T) {
+			mnemonic, err := packed.EncodeToMnemonic(tt.data)
+			if err != nil {
+				t.Fatalf("encode failed: %v", err)
+			}
+
+			decoded, err := packed.DecodeFromMnemonic(mnemonic)
+			if err != nil {
+				t.Fatalf("decode failed: %v", err)
+			}
+
+			if !bytes.Equal(decoded, tt.data) {
+				t.Errorf("expected %v, got %v", tt.data, decoded)
+			}
+		})
+	}
 }
@@ -0,0 +1,488 @@
+package pvss
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+)
+
+// Transcript is a Fiat–Shamir transcript: the running record of a
+// non-interactive proof, from which challenges are derived. Every message
+// is labelled and length-prefixed, and the transcript starts with a domain
+// naming the protocol, so a proof made for one protocol, statement or
+// context never verifies for another. Prover and verifier must append the
+// same messages in the same order; a proof function consumes the
+// transcript it is given.
+type Transcript struct {
+	data []byte
+}
+
+// NewTranscript starts a transcript for the protocol named by domain, for
+// example "example.com/voting/ballot".
+func NewTranscript(domain string) *Transcript {
+	t := &Transcript{}
+	t.AppendMessage("domain", []byte(domain))
+	return t
+}
+
+// AppendMessage adds a labelled message, such as a session ID or context
+// the proof should be bound to.
+func (t *Transcript) AppendMessage(label string, message []byte) {
+	t.data = binary.BigEndian.AppendUint32(t.data, uint32(len(label)))
+	t.data = append(t.data, label...)
+	t.data = binary.BigEndian.AppendUint32(t.data, uint32(len(message)))
+	t.data = append(t.data, message...)
+}
+
+// AppendElement adds a labelled group element.
+func (t *Transcript) AppendElement(label string, element Element) {
+	t.AppendMessage(label, element.Bytes())
+}
+
+// AppendScalar adds a labelled scalar.
+func (t *Transcript) AppendScalar(label string, scalar *Scalar) {
+	t.AppendMessage(label, scalar.Bytes())
+}
+
+// Challenge derives a labelled scalar of g from everything appended so far,
+// then appends it, so successive challenges differ. The digest is expanded
+// 16 bytes past the order's size before reduction, so the challenge is
+// close to uniform.
+func (t *Transcript) Challenge(label string, g Group) *Scalar {
+	t.AppendMessage("challenge", []byte(label))
+
+	field := scalarFieldOf(g)
+	size := field.size + 16
+	digest := make([]byte, 0, size+sha256.Size)
+	for block := uint32(0); len(digest) < size; block++ {
+		sum := sha256.Sum256(binary.BigEndian.AppendUint32(append([]byte{}, t.data...), block))
+		digest = append(digest, sum[:]...)
+	}
+
+	reduced := new(big.Int).SetBytes(digest[:size])
+	reduced.Mod(reduced, g.Order())
+
+	challenge, err := field.newScalar().SetBytes(reduced.FillBytes(make([]byte, field.size)))
+	if err != nil {
+		panic("pvss: reduced challenge exceeds the group order")
+	}
+
+	t.AppendScalar(label, challenge)
+	return challenge
+}
+
+// Clone returns an independent copy of the transcript, for deriving several
+// proofs from a common prefix.
+func (t *Transcript) Clone() *Transcript {
+	return &Transcript{data: append([]byte{}, t.data...)}
+}
+
+// DLEQStatement claims log_G1 H1 = log_G2 H2: one scalar x with H1 = G1·x
+// and H2 = G2·x.
+type DLEQStatement struct {
+	G1, H1 Element
+	G2, H2 Element
+}
+
+// relation claims one witness x with publics[i] = bases[i]·x for every i.
+// A Schnorr statement has one pair and a DLEQ statement two.
+type relation struct {
+	bases   []Element
+	publics []Element
+}
+
+func schnorrRelation(base, public Element) relation {
+	return relation{bases: []Element{base}, publics: []Element{public}}
+}
+
+func (s DLEQStatement) relation() relation {
+	return relation{bases: []Element{s.G1, s.G2}, publics: []Element{s.H1, s.H2}}
+}
+
+func (r relation) appendTo(t *Transcript) {
+	for i := range r.bases {
+		t.AppendElement("base", r.bases[i])
+		t.AppendElement("public", r.publics[i])
+	}
+}
+
+// commit returns bases[i]·nonce, the prover's first message.
+func (r relation) commit(nonce *Scalar) []Element {
+	commitments := make([]Element, len(r.bases))
+	for i, base := range r.bases {
+		commitments[i] = base.ScalarMult(nonce)
+	}
+	return commitments
+}
+
+// recommit returns bases[i]·response + publics[i]·challenge, which equals
+// the prover's commitments when the response is valid.
+func (r relation) recommit(challenge, response *Scalar) []Element {
+	commitments := make([]Element, len(r.bases))
+	for i, base := range r.bases {
+		commitments[i] = base.ScalarMult(response).Add(r.publics[i].ScalarMult(challenge))
+	}
+	return commitments
+}
+
+func appendCommitments(t *Transcript, commitments []Element) {
+	for _, commitment := range commitments {
+		t.AppendElement("commitment", commitment)
+	}
+}
+
+// respond returns nonce − challenge·witness.
+func respond(field *scalarField, nonce, challenge, witness *Scalar) *Scalar {
+	response := field.newScalar().Multiply(challenge, witness)
+	return response.Subtract(nonce, response)
+}
+
+// proveBatch proves every relation with one challenge, returning it and a
+// response for each relation.
+func proveBatch(g Group, t *Transcript, kind string, relations []relation, witnesses []*Scalar) (*Scalar, []*Scalar, error) {
+	if len(relations) == 0 || len(relations) != len(witnesses) {
+		return nil, nil, errors.New("mismatched statements and witnesses")
+	}
+	field := scalarFieldOf(g)
+
+	t.AppendMessage("proof", []byte(kind))
+	for _, r := range relations {
+		r.appendTo(t)
+	}
+
+	nonces := make([]*Scalar, len(relations))
+	for i, r := range relations {
+		nonce, err := field.random()
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to generate nonce: %v", err)
+		}
+		nonces[i] = nonce
+		appendCommitments(t, r.commit(nonce))
+	}
+
+	challenge := t.Challenge("challenge", g)
+	responses := make([]*Scalar, len(relations))
+	for i := range relations {
+		responses[i] = respond(field, nonces[i], challenge, witnesses[i])
+	}
+
+	return challenge, responses, nil
+}
+
+// verifyBatch checks a proof made by proveBatch.
+func verifyBatch(g Group, t *Transcript, kind string, relations []relation, challenge *Scalar, responses []*Scalar) bool {
+	if len(relations) == 0 || len(relations) != len(responses) || !allSet(challenge) || !allSet(responses...) {
+		return false
+	}
+
+	t.AppendMessage("proof", []byte(kind))
+	for _, r := range relations {
+		r.appendTo(t)
+	}
+	for i, r := range relations {
+		appendCommitments(t, r.recommit(challenge, responses[i]))
+	}
+
+	return t.Challenge("challenge", g).Equal(challenge)
+}
+
+// SchnorrProof proves knowledge of x with public = base·x, without
+// revealing x.
+type SchnorrProof struct {
+	challenge *Scalar
+	response  *Scalar
+}
+
+// ProveSchnorr proves knowledge of x with public = base·x.
+func ProveSchnorr(g Group, t *Transcript, base, public Element, x *Scalar) (*SchnorrProof, error) {
+	challenge, responses, err := proveBatch(g, t, "schnorr", []relation{schnorrRelation(base, public)}, []*Scalar{x})
+	if err != nil {
+		return nil, err
+	}
+	return &SchnorrProof{challenge: challenge, response: responses[0]}, nil
+}
+
+// VerifySchnorr checks a proof made by ProveSchnorr.
+func VerifySchnorr(g Group, t *Transcript, base, public Element, proof *SchnorrProof) bool {
+	if proof == nil {
+		return false
+	}
+	return verifyBatch(g, t, "schnorr", []relation{schnorrRelation(base, public)}, proof.challenge, []*Scalar{proof.response})
+}
+
+// Bytes returns the challenge and response at their fixed width, the
+// encoding read by ParseSchnorrProof.
+func (p *SchnorrProof) Bytes() []byte {
+	return appendFixedScalars(nil, p.challenge, p.response)
+}
+
+// ParseSchnorrProof decodes a proof over g produced by SchnorrProof.Bytes.
+func ParseSchnorrProof(g Group, data []byte) (*SchnorrProof, error) {
+	scalars, err := readFixedScalars(g, data, 2)
+	if err != nil {
+		return nil, err
+	}
+	return &SchnorrProof{challenge: scalars[0], response: scalars[1]}, nil
+}
+
+// DLEQProof is a Chaum–Pedersen proof of a DLEQStatement, which shows the
+// two logarithms are equal without revealing them.
+type DLEQProof struct {
+	challenge *Scalar
+	response  *Scalar
+}
+
+// ProveDLEQ proves statement with its witness x.
+func ProveDLEQ(g Group, t *Transcript, statement DLEQStatement, x *Scalar) (*DLEQProof, error) {
+	challenge, responses, err := proveBatch(g, t, "dleq", []relation{statement.relation()}, []*Scalar{x})
+	if err != nil {
+		return nil, err
+	}
+	return &DLEQProof{challenge: challenge, response: responses[0]}, nil
+}
+
+// VerifyDLEQ checks a proof made by ProveDLEQ.
+func VerifyDLEQ(g Group, t *Transcript, statement DLEQStatement, proof *DLEQProof) bool {
+	if proof == nil {
+		return false
+	}
+	return verifyBatch(g, t, "dleq", []relation{statement.relation()}, proof.challenge, []*Scalar{proof.response})
+}
+
+// Bytes returns the challenge and response at their fixed width, the
+// encoding read by ParseDLEQProof.
+func (p *DLEQProof) Bytes() []byte {
+	return appendFixedScalars(nil, p.challenge, p.response)
+}
+
+// ParseDLEQProof decodes a proof over g produced by DLEQProof.Bytes.
+func ParseDLEQProof(g Group, data []byte) (*DLEQProof, error) {
+	scalars, err := readFixedScalars(g, data, 2)
+	if err != nil {
+		return nil, err
+	}
+	return &DLEQProof{challenge: scalars[0], response: scalars[1]}, nil
+}
+
+// BatchDLEQProof proves several DLEQ statements, each with its own witness,
+// under one challenge. It takes n+1 scalars instead of 2n.
+type BatchDLEQProof struct {
+	challenge *Scalar
+	responses []*Scalar
+}
+
+// ProveBatchDLEQ proves every statement with the witness at the same index.
+func ProveBatchDLEQ(g Group, t *Transcript, statements []DLEQStatement, witnesses []*Scalar) (*BatchDLEQProof, error) {
+	challenge, responses, err := proveBatch(g, t, "dleq-batch", dleqRelations(statements), witnesses)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchDLEQProof{challenge: challenge, responses: responses}, nil
+}
+
+// VerifyBatchDLEQ checks a proof made by ProveBatchDLEQ. It fails unless
+// every statement holds.
+func VerifyBatchDLEQ(g Group, t *Transcript, statements []DLEQStatement, proof *BatchDLEQProof) bool {
+	if proof == nil {
+		return false
+	}
+	return verifyBatch(g, t, "dleq-batch", dleqRelations(statements), proof.challenge, proof.responses)
+}
+
+// Bytes returns [statement count][challenge][responses], with scalars at
+// their fixed width, the encoding read by ParseBatchDLEQProof.
+func (p *BatchDLEQProof) Bytes() []byte {
+	result := appendCount(nil, len(p.responses))
+	result = appendFixedScalars(result, p.challenge)
+	return appendFixedScalars(result, p.responses...)
+}
+
+// ParseBatchDLEQProof decodes a proof over g produced by
+// BatchDLEQProof.Bytes.
+func ParseBatchDLEQProof(g Group, data []byte) (*BatchDLEQProof, error) {
+	count, offset, err := readCount(data, 0)
+	if err != nil || count < 1 {
+		return nil, errors.New("invalid statement count")
+	}
+	scalars, err := readFixedScalars(g, data[offset:], count+1)
+	if err != nil {
+		return nil, err
+	}
+	return &BatchDLEQProof{challenge: scalars[0], responses: scalars[1:]}, nil
+}
+
+func dleqRelations(statements []DLEQStatement) []relation {
+	relations := make([]relation, len(statements))
+	for i, statement := range statements {
+		relations[i] = statement.relation()
+	}
+	return relations
+}
+
+// ORProof proves that one of several statements holds without revealing
+// which, following Cramer, Damgård and Schoenmakers: the prover simulates
+// every other statement with a challenge of its choosing, and the
+// challenges must sum to the transcript's.
+type ORProof struct {
+	challenges []*Scalar
+	responses  []*Scalar
+}
+
+// ProveSchnorrOR proves knowledge of x with publics[index] = base·x, without
+// revealing index.
+func ProveSchnorrOR(g Group, t *Transcript, base Element, publics []Element, index int, x *Scalar) (*ORProof, error) {
+	relations := make([]relation, len(publics))
+	for i, public := range publics {
+		relations[i] = schnorrRelation(base, public)
+	}
+	return proveOR(g, t, "schnorr-or", relations, index, x)
+}
+
+// VerifySchnorrOR checks a proof made by ProveSchnorrOR.
+func VerifySchnorrOR(g Group, t *Transcript, base Element, publics []Element, proof *ORProof) bool {
+	relations := make([]relation, len(publics))
+	for i, public := range publics {
+		relations[i] = schnorrRelation(base, public)
+	}
+	return verifyOR(g, t, "schnorr-or", relations, proof)
+}
+
+// ProveDLEQOR proves statements[index] with its witness x, without
+// revealing index.
+func ProveDLEQOR(g Group, t *Transcript, statements []DLEQStatement, index int, x *Scalar) (*ORProof, error) {
+	return proveOR(g, t, "dleq-or", dleqRelations(statements), index, x)
+}
+
+// VerifyDLEQOR checks a proof made by ProveDLEQOR.
+func VerifyDLEQOR(g Group, t *Transcript, statements []DLEQStatement, proof *ORProof) bool {
+	return verifyOR(g, t, "dleq-or", dleqRelations(statements), proof)
+}
+
+func proveOR(g Group, t *Transcript, kind string, relations []relation, index int, x *Scalar) (*ORProof, error) {
+	if index < 0 || index >= len(relations) {
+		return nil, fmt.Errorf("statement index %d out of range", index)
+	}
+	field := scalarFieldOf(g)
+
+	t.AppendMessage("proof", []byte(kind))
+	for _, r := range relations {
+		r.appendTo(t)
+	}
+
+	proof := &ORProof{
+		challenges: make([]*Scalar, len(relations)),
+		responses:  make([]*Scalar, len(relations)),
+	}
+	var nonce *Scalar
+	for i, r := range relations {
+		var err error
+		if i == index {
+			if nonce, err = field.random(); err != nil {
+				return nil, fmt.Errorf("failed to generate nonce: %v", err)
+			}
+			appendCommitments(t, r.commit(nonce))
+			continue
+		}
+
+		// Simulate: pick the challenge and response, derive the commitments
+		if proof.challenges[i], err = field.random(); err != nil {
+			return nil, fmt.Errorf("failed to generate challenge: %v", err)
+		}
+		if proof.responses[i], err = field.random(); err != nil {
+			return nil, fmt.Errorf("failed to generate response: %v", err)
+		}
+		appendCommitments(t, r.recommit(proof.challenges[i], proof.responses[i]))
+	}
+
+	challenge := t.Challenge("challenge", g)
+	for i, c := range proof.challenges {
+		if i != index {
+			challenge.Subtract(challenge, c)
+		}
+	}
+	proof.challenges[index] = challenge
+	proof.responses[index] = respond(field, nonce, challenge, x)
+
+	return proof, nil
+}
+
+func verifyOR(g Group, t *Transcript, kind string, relations []relation, proof *ORProof) bool {
+	if proof == nil || len(relations) == 0 || len(proof.challenges) != len(relations) || len(proof.responses) != len(relations) {
+		return false
+	}
+	if !allSet(proof.challenges...) || !allSet(proof.responses...) {
+		return false
+	}
+
+	t.AppendMessage("proof", []byte(kind))
+	for _, r := range relations {
+		r.appendTo(t)
+	}
+	sum := scalarFieldOf(g).newScalar()
+	for i, r := range relations {
+		appendCommitments(t, r.recommit(proof.challenges[i], proof.responses[i]))
+		sum.Add(sum, proof.challenges[i])
+	}
+
+	return t.Challenge("challenge", g).Equal(sum)
+}
+
+// Bytes returns [statement count][challenges][responses], with scalars at
+// their fixed width, the encoding read by ParseORProof.
+func (p *ORProof) Bytes() []byte {
+	result := appendCount(nil, len(p.challenges))
+	result = appendFixedScalars(result, p.challenges...)
+	return appendFixedScalars(result, p.responses...)
+}
+
+// ParseORProof decodes a proof over g produced by ORProof.Bytes.
+func ParseORProof(g Group, data []byte) (*ORProof, error) {
+	count, offset, err := readCount(data, 0)
+	if err != nil || count < 1 {
+		return nil, errors.New("invalid statement count")
+	}
+	scalars, err := readFixedScalars(g, data[offset:], 2*count)
+	if err != nil {
+		return nil, err
+	}
+	return &ORProof{challenges: scalars[:count], responses: scalars[count:]}, nil
+}
+
+// allSet reports whether none of scalars is nil, as in a zero-value proof.
+func allSet(scalars ...*Scalar) bool {
+	for _, scalar := range scalars {
+		if scalar == nil {
+			return false
+		}
+	}
+	return true
+}
+
+// appendFixedScalars writes scalars at their fixed width.
+func appendFixedScalars(dst []byte, scalars ...*Scalar) []byte {
+	for _, scalar := range scalars {
+		dst = append(dst, scalar.Bytes()...)
+	}
+	return dst
+}
+
+// readFixedScalars parses exactly count scalars of g written by
+// appendFixedScalars, rejecting values that are not below the order.
+func readFixedScalars(g Group, data []byte, count int) ([]*Scalar, error) {
+	field := scalarFieldOf(g)
+	if len(data) != count*field.size {
+		return nil, fmt.Errorf("proof size mismatch: expected %d, got %d", count*field.size, len(data))
+	}
+
+	scalars := make([]*Scalar, count)
+	for i := range scalars {
+		scalar, err := field.newScalar().SetBytes(data[i*field.size : (i+1)*field.size])
+		if err != nil {
+			return nil, err
+		}
+		scalars[i] = scalar
+	}
+	return scalars, nil
+}
@@ -0,0 +1,356 @@
+package pvss
+
+import (
+	"bytes"
+	"testing"
+)
+
+// proofWitness returns a random witness of g and base·witness
+func proofWitness(t *testing.T, g Group, base Element) (*Scalar, Element) {
+	t.Helper()
+
+	x, err := RandomScalar(g)
+	if err != nil {
+		t.Fatalf("RandomScalar failed: %v", err)
+	}
+	return x, base.ScalarMult(x)
+}
+
+// proofBase returns a second base of g whose logarithm is not used
+func proofBase(t *testing.T, g Group) Element {
+	t.Helper()
+
+	k, err := RandomScalar(g)
+	if err != nil {
+		t.Fatalf("RandomScalar failed: %v", err)
+	}
+	return g.Generator().ScalarMult(k)
+}
+
+// TestTranscript tests that challenges depend on the domain, every message
+// and the label, and that clones evolve independently
+func TestTranscript(t *testing.T) {
+	g := P256()
+
+	challenge := func(domain string, messages ...string) *Scalar {
+		tr := NewTranscript(domain)
+		for _, message := range messages {
+			tr.AppendMessage("message", []byte(message))
+		}
+		return tr.Challenge("c", g)
+	}
+
+	base := challenge("test", "a", "b")
+	if !base.Equal(challenge("test", "a", "b")) {
+		t.Error("challenge is not deterministic")
+	}
+	for name, other := range map[string]*Scalar{
+		"domain":   challenge("other", "a", "b"),
+		"message":  challenge("test", "a", "c"),
+		"boundary": challenge("test", "ab", ""),
+		"count":    challenge("test", "a", "b", ""),
+	} {
+		if base.Equal(other) {
+			t.Errorf("changing the %s did not change the challenge", name)
+		}
+	}
+
+	tr := NewTranscript("test")
+	clone := tr.Clone()
+	first := tr.Challenge("c", g)
+	if !first.Equal(clone.Challenge("c", g)) {
+		t.Error("clone diverged before any change")
+	}
+	if first.Equal(tr.Challenge("c", g)) {
+		t.Error("successive challenges are equal")
+	}
+	clone.AppendMessage("extra", nil)
+	if tr.Challenge("c", g).Equal(clone.Challenge("c", g)) {
+		t.Error("clone is not independent")
+	}
+}
+
+// TestSchnorrProof tests proving, verifying and encoding knowledge of a
+// discrete logarithm
+func TestSchnorrProof(t *testing.T) {
+	for _, g := range allGroups() {
+		t.Run(g.Name(), func(t *testing.T) {
+			base := g.Generator()
+			x, public := proofWitness(t, g, base)
+
+			proof, err := ProveSchnorr(g, NewTranscript("test"), base, public, x)
+			if err != nil {
+				t.Fatalf("ProveSchnorr failed: %v", err)
+			}
+			if len(proof.Bytes()) != 2*x.Size() {
+				t.Errorf("encoded size: got %d, want %d", len(proof.Bytes()), 2*x.Size())
+			}
+			parsed, err := ParseSchnorrProof(g, proof.Bytes())
+			if err != nil {
+				t.Fatalf("ParseSchnorrProof failed: %v", err)
+			}
+			if !bytes.Equal(parsed.Bytes(), proof.Bytes()) {
+				t.Error("encoding does not round-trip")
+			}
+
+			if !VerifySchnorr(g, NewTranscript("test"), base, public, parsed) {
+				t.Error("valid proof rejected")
+			}
+			if VerifySchnorr(g, NewTranscript("other"), base, public, parsed) {
+				t.Error("proof verified under another domain")
+			}
+			if VerifySchnorr(g, NewTranscript("test"), base, public.Add(base), parsed) {
+				t.Error("proof verified for another public value")
+			}
+
+			// Knowing some other logarithm proves nothing
+			y, _ := proofWitness(t, g, base)
+			forged, err := ProveSchnorr(g, NewTranscript("test"), base, public, y)
+			if err != nil {
+				t.Fatalf("ProveSchnorr failed: %v", err)
+			}
+			if VerifySchnorr(g, NewTranscript("test"), base, public, forged) {
+				t.Error("proof with the wrong witness verified")
+			}
+		})
+	}
+}
+
+// TestDLEQProof tests Chaum–Pedersen proofs of equal logarithms
+func TestDLEQProof(t *testing.T) {
+	for _, g := range allGroups() {
+		t.Run(g.Name(), func(t *testing.T) {
+			g1, g2 := g.Generator(), proofBase(t, g)
+			x, h1 := proofWitness(t, g, g1)
+			statement := DLEQStatement{G1: g1, H1: h1, G2: g2, H2: g2.ScalarMult(x)}
+
+			tr := NewTranscript("test")
+			tr.AppendMessage("session", []byte{1})
+			proof, err := ProveDLEQ(g, tr, statement, x)
+			if err != nil {
+				t.Fatalf("ProveDLEQ failed: %v", err)
+			}
+			parsed, err := ParseDLEQProof(g, proof.Bytes())
+			if err != nil {
+				t.Fatalf("ParseDLEQProof failed: %v", err)
+			}
+
+			tr = NewTranscript("test")
+			tr.AppendMessage("session", []byte{1})
+			if !VerifyDLEQ(g, tr, statement, parsed) {
+				t.Error("valid proof rejected")
+			}
+
+			tr = NewTranscript("test")
+			tr.AppendMessage("session", []byte{2})
+			if VerifyDLEQ(g, tr, statement, parsed) {
+				t.Error("proof verified in another session")
+			}
+
+			// Different logarithms cannot be proven equal
+			unequal := statement
+			unequal.H2 = statement.H2.Add(g2)
+			bad, err := ProveDLEQ(g, NewTranscript("test"), unequal, x)
+			if err != nil {
+				t.Fatalf("ProveDLEQ failed: %v", err)
+			}
+			if VerifyDLEQ(g, NewTranscript("test"), unequal, bad) {
+				t.Error("proof of unequal logarithms verified")
+			}
+
+			// A Schnorr proof is not a DLEQ proof, even with matching bytes
+			schnorr, err := ProveSchnorr(g, NewTranscript("test"), g1, h1, x)
+			if err != nil {
+				t.Fatalf("ProveSchnorr failed: %v", err)
+			}
+			reused, err := ParseDLEQProof(g, schnorr.Bytes())
+			if err != nil {
+				t.Fatalf("ParseDLEQProof failed: %v", err)
+			}
+			if VerifyDLEQ(g, NewTranscript("test"), statement, reused) {
+				t.Error("Schnorr proof verified as a DLEQ proof")
+			}
+		})
+	}
+}
+
+// TestBatchDLEQProof tests several statements proven under one challenge
+func TestBatchDLEQProof(t *testing.T) {
+	g := Ristretto255()
+	g1 := g.Generator()
+
+	statements := make([]DLEQStatement, 4)
+	witnesses := make([]*Scalar, len(statements))
+	for i := range statements {
+		g2 := proofBase(t, g)
+		x, h1 := proofWitness(t, g, g1)
+		witnesses[i] = x
+		statements[i] = DLEQStatement{G1: g1, H1: h1, G2: g2, H2: g2.ScalarMult(x)}
+	}
+
+	proof, err := ProveBatchDLEQ(g, NewTranscript("test"), statements, witnesses)
+	if err != nil {
+		t.Fatalf("ProveBatchDLEQ failed: %v", err)
+	}
+	if want := 1 + 5*witnesses[0].Size(); len(proof.Bytes()) != want {
+		t.Errorf("encoded size: got %d, want %d", len(proof.Bytes()), want)
+	}
+	parsed, err := ParseBatchDLEQProof(g, proof.Bytes())
+	if err != nil {
+		t.Fatalf("ParseBatchDLEQProof failed: %v", err)
+	}
+	if !VerifyBatchDLEQ(g, NewTranscript("test"), statements, parsed) {
+		t.Error("valid proof rejected")
+	}
+
+	if VerifyBatchDLEQ(g, NewTranscript("test"), statements[:3], parsed) {
+		t.Error("proof verified for fewer statements")
+	}
+	swapped := append([]DLEQStatement{}, statements...)
+	swapped[0], swapped[1] = swapped[1], swapped[0]
+	if VerifyBatchDLEQ(g, NewTranscript("test"), swapped, parsed) {
+		t.Error("proof verified for reordered statements")
+	}
+
+	// One false statement spoils the batch
+	witnesses[2] = NewScalar(g).Add(witnesses[2], NewScalar(g).SetUint64(1))
+	bad, err := ProveBatchDLEQ(g, NewTranscript("test"), statements, witnesses)
+	if err != nil {
+		t.Fatalf("ProveBatchDLEQ failed: %v", err)
+	}
+	if VerifyBatchDLEQ(g, NewTranscript("test"), statements, bad) {
+		t.Error("batch with a wrong witness verified")
+	}
+
+	if _, err := ProveBatchDLEQ(g, NewTranscript("test"), statements, witnesses[:3]); err == nil {
+		t.Error("expected error for mismatched witnesses")
+	}
+}
+
+// TestORProof tests one-of-n proofs for every index, and a DLEQ OR proof
+// that an encrypted value is a bit
+func TestORProof(t *testing.T) {
+	g := Secp256k1()
+	base := g.Generator()
+
+	publics := make([]Element, 3)
+	witnesses := make([]*Scalar, len(publics))
+	for i := range publics {
+		witnesses[i], publics[i] = proofWitness(t, g, base)
+	}
+
+	for index, x := range witnesses {
+		proof, err := ProveSchnorrOR(g, NewTranscript("test"), base, publics, index, x)
+		if err != nil {
+			t.Fatalf("ProveSchnorrOR failed: %v", err)
+		}
+		parsed, err := ParseORProof(g, proof.Bytes())
+		if err != nil {
+			t.Fatalf("ParseORProof failed: %v", err)
+		}
+		if !VerifySchnorrOR(g, NewTranscript("test"), base, publics, parsed) {
+			t.Errorf("index %d: valid proof rejected", index)
+		}
+		if VerifySchnorrOR(g, NewTranscript("test"), base, publics[:2], parsed) {
+			t.Errorf("index %d: proof verified for other statements", index)
+		}
+	}
+
+	// Claiming an index without its witness fails
+	proof, err := ProveSchnorrOR(g, NewTranscript("test"), base, publics, 0, witnesses[1])
+	if err != nil {
+		t.Fatalf("ProveSchnorrOR failed: %v", err)
+	}
+	if VerifySchnorrOR(g, NewTranscript("test"), base, publics, proof) {
+		t.Error("proof without a witness verified")
+	}
+	if _, err := ProveSchnorrOR(g, NewTranscript("test"), base, publics, 3, witnesses[0]); err == nil {
+		t.Error("expected error for an index out of range")
+	}
+
+	// An encrypted bit: (R, C) = (g·r, y·r + g·m) with m ∈ {0, 1}, proven
+	// without revealing m by showing log_g R = log_y (C − g·m) for some m
+	y := proofBase(t, g)
+	r, R := proofWitness(t, g, base)
+	C := y.ScalarMult(r).Add(base)
+	statements := []DLEQStatement{
+		{G1: base, H1: R, G2: y, H2: C},
+		{G1: base, H1: R, G2: y, H2: C.Add(base.ScalarMult(NewScalar(g).Negate(NewScalar(g).SetUint64(1))))},
+	}
+	bit, err := ProveDLEQOR(g, NewTranscript("ballot"), statements, 1, r)
+	if err != nil {
+		t.Fatalf("ProveDLEQOR failed: %v", err)
+	}
+	if !VerifyDLEQOR(g, NewTranscript("ballot"), statements, bit) {
+		t.Error("valid ballot proof rejected")
+	}
+	if VerifySchnorrOR(g, NewTranscript("ballot"), base, []Element{R, R}, bit) {
+		t.Error("DLEQ OR proof verified as a Schnorr OR proof")
+	}
+}
+
+// TestProof_Parse tests that malformed encodings are rejected
+func TestProof_Parse(t *testing.T) {
+	g := P256()
+	size := NewScalar(g).Size()
+	order := g.Order().FillBytes(make([]byte, size))
+
+	valid := make([]byte, 2*size)
+	if _, err := ParseSchnorrProof(g, valid); err != nil {
+		t.Errorf("zero proof: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		parse func([]byte) error
+		data  []byte
+	}{
+		{"schnorr short", func(d []byte) error { _, err := ParseSchnorrProof(g, d); return err }, valid[1:]},
+		{"schnorr trailing", func(d []byte) error { _, err := ParseSchnorrProof(g, d); return err }, append(valid, 0)},
+		{"dleq unreduced", func(d []byte) error { _, err := ParseDLEQProof(g, d); return err }, append(order, valid[size:]...)},
+		{"batch empty", func(d []byte) error { _, err := ParseBatchDLEQProof(g, d); return err }, nil},
+		{"batch zero count", func(d []byte) error { _, err := ParseBatchDLEQProof(g, d); return err }, []byte{0}},
+		{"batch short", func(d []byte) error { _, err := ParseBatchDLEQProof(g, d); return err }, append([]byte{2}, valid...)},
+		{"or short", func(d []byte) error { _, err := ParseORProof(g, d); return err }, append([]byte{2}, valid[1:]...)},
+	}
+	for _, tt := range tests {
+		if err := tt.parse(tt.data); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+}
+
+// TestProof_NilProofs tests that every verifier rejects nil and zero-value
+// proofs instead of panicking
+func TestProof_NilProofs(t *testing.T) {
+	g := P256()
+	base := g.Generator()
+	_, public := proofWitness(t, g, base)
+	statement := DLEQStatement{G1: base, H1: public, G2: base, H2: public}
+	tr := func() *Transcript { return NewTranscript("test/nil") }
+
+	tests := []struct {
+		name   string
+		verify func() bool
+	}{
+		{"schnorr nil", func() bool { return VerifySchnorr(g, tr(), base, public, nil) }},
+		{"schnorr zero", func() bool { return VerifySchnorr(g, tr(), base, public, &SchnorrProof{}) }},
+		{"dleq nil", func() bool { return VerifyDLEQ(g, tr(), statement, nil) }},
+		{"dleq zero", func() bool { return VerifyDLEQ(g, tr(), statement, &DLEQProof{}) }},
+		{"batch nil", func() bool { return VerifyBatchDLEQ(g, tr(), []DLEQStatement{statement}, nil) }},
+		{"batch zero", func() bool { return VerifyBatchDLEQ(g, tr(), []DLEQStatement{statement}, &BatchDLEQProof{}) }},
+		{"batch nil response", func() bool {
+			return VerifyBatchDLEQ(g, tr(), []DLEQStatement{statement}, &BatchDLEQProof{challenge: NewScalar(g), responses: []*Scalar{nil}})
+		}},
+		{"or nil", func() bool { return VerifySchnorrOR(g, tr(), base, []Element{public}, nil) }},
+		{"or zero", func() bool { return VerifyDLEQOR(g, tr(), []DLEQStatement{statement}, &ORProof{}) }},
+		{"or nil challenge", func() bool {
+			return VerifySchnorrOR(g, tr(), base, []Element{public}, &ORProof{challenges: []*Scalar{nil}, responses: []*Scalar{NewScalar(g)}})
+		}},
+	}
+	for _, tt := range tests {
+		if tt.verify() {
+			t.Errorf("%s: verified", tt.name)
+		}
+	}
+}
@@ -0,0 +1,449 @@
+package pvss
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+)
+
+// Domains separating the Fiat–Shamir transcripts of publicly verifiable
+// sharing from each other and from any other protocol.
+const (
+	publicShareDomain      = "pvss/public/share"
+	publicDecryptionDomain = "pvss/public/decryption"
+	publicSecretDomain     = "pvss/public/secret"
+)
+
+// ParticipantKey is a key pair for receiving encrypted shares. Public is
+// Private times the group generator.
+type ParticipantKey struct {
+	Private *Scalar
+	Public  Element
+}
+
+// GenerateParticipantKey returns a fresh key pair in the instance's group.
+func (pvss *PedersenVSS) GenerateParticipantKey() (*ParticipantKey, error) {
+	private, err := pvss.randomScalar()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate private key: %v", err)
+	}
+	if private.IsZero() {
+		return nil, errors.New("generated private key is zero")
+	}
+
+	return &ParticipantKey{Private: private, Public: pvss.group.Generator().ScalarMult(private)}, nil
+}
+
+// PublicDealing is a secret shared with Schoenmakers' publicly verifiable
+// scheme: commitments to the sharing polynomial, each share encrypted to its
+// participant's public key with a proof that it matches the commitments,
+// and the secret sealed under a key only threshold participants can
+// recover. Anyone can check it with VerifyPublicDealing.
+type PublicDealing struct {
+	threshold   int
+	publicKeys  []Element       // Public key of participant i at index i−1
+	commitments []Element       // h·a_j for the polynomial coefficients a_j
+	encrypted   []Element       // Y_i = y_i·p(i) for public key y_i
+	proof       *BatchDLEQProof // log_h X_i = log_y_i Y_i for every i, with X_i = h·p(i)
+	ciphertext  []byte          // Nonce followed by the sealed secret
+	body        int             // Length of the encoding before the ciphertext
+	raw         []byte
+}
+
+// Threshold returns the number of decrypted shares needed to recover the
+// secret.
+func (d *PublicDealing) Threshold() int {
+	return d.threshold
+}
+
+// PublicKeys returns the participants' public keys; participant i's key is
+// at index i−1.
+func (d *PublicDealing) PublicKeys() []Element {
+	return append([]Element{}, d.publicKeys...)
+}
+
+// Bytes returns the encoding read by ParsePublicDealing.
+func (d *PublicDealing) Bytes() []byte {
+	return append([]byte{}, d.raw...)
+}
+
+// DecryptedShare is a participant's share of a PublicDealing, decrypted
+// with its private key, with a proof that the decryption is correct.
+type DecryptedShare struct {
+	id    int
+	value Element // S_i = g·p(i), with g the group generator
+	proof *DLEQProof
+	raw   []byte
+}
+
+// ID returns the participant ID of the share.
+func (s *DecryptedShare) ID() int {
+	return s.id
+}
+
+// Bytes returns the encoding read by ParseDecryptedShare.
+func (s *DecryptedShare) Bytes() []byte {
+	return append([]byte{}, s.raw...)
+}
+
+// DealPublic shares secret among the holders of publicKeys, following
+// Schoenmakers. Participant i, with i from 1, holds publicKeys[i−1]. Each
+// share is encrypted to its participant and proven, with a batched
+// Chaum–Pedersen proof, to match the commitments, so anyone can verify the
+// dealing without holding a share. The secret itself is sealed with AES-GCM
+// under a key derived from g·s, where s is the shared value.
+func (pvss *PedersenVSS) DealPublic(secret []byte, publicKeys []Element, threshold int) (*PublicDealing, error) {
+	if threshold > len(publicKeys) {
+		return nil, errors.New("threshold cannot be greater than number of shares")
+	}
+	if threshold < 1 {
+		return nil, errors.New("threshold must be at least 1")
+	}
+	if len(publicKeys) > maxShares {
+		return nil, fmt.Errorf("number of shares cannot exceed %d", maxShares)
+	}
+	if len(secret) == 0 {
+		return nil, errors.New("secret cannot be empty")
+	}
+	identity := pvss.group.Identity()
+	for i, key := range publicKeys {
+		if key.Equal(identity) {
+			return nil, fmt.Errorf("public key of participant %d is the identity", i+1)
+		}
+	}
+
+	value, err := pvss.randomScalar()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate shared value: %v", err)
+	}
+	coefficients, err := pvss.generateRandomPolynomial(value, threshold)
+	if err != nil {
+		return nil, err
+	}
+
+	dealing := &PublicDealing{
+		threshold:   threshold,
+		publicKeys:  append([]Element{}, publicKeys...),
+		commitments: make([]Element, threshold),
+		encrypted:   make([]Element, len(publicKeys)),
+	}
+	for j, coefficient := range coefficients {
+		dealing.commitments[j] = pvss.h.ScalarMult(coefficient)
+	}
+
+	shares := make([]*Scalar, len(publicKeys))
+	for i, key := range publicKeys {
+		shares[i] = pvss.evaluatePolynomial(coefficients, i+1)
+		dealing.encrypted[i] = key.ScalarMult(shares[i])
+	}
+
+	dealing.proof, err = ProveBatchDLEQ(pvss.group, NewTranscript(publicShareDomain), pvss.shareStatements(dealing), shares)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prove shares: %v", err)
+	}
+
+	body := pvss.serializePublicDealingBody(dealing)
+
+	gcm, err := newEnvelopeCipher(pvss.publicSecretKey(pvss.group.Generator().ScalarMult(value)))
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %v", err)
+	}
+	dealing.ciphertext = gcm.Seal(nonce, nonce, secret, body)
+
+	dealing.body = len(body)
+	dealing.raw = append(body, dealing.ciphertext...)
+
+	return dealing, nil
+}
+
+// VerifyPublicDealing checks the encrypted shares of a dealing against its
+// commitments. It needs no share and no private key, so any auditor can
+// run it. Malformed dealings return an error; well-formed dealings with a
+// share that does not match the commitments return false.
+func (pvss *PedersenVSS) VerifyPublicDealing(dealing *PublicDealing) (bool, error) {
+	if len(dealing.commitments) != dealing.threshold || len(dealing.encrypted) != len(dealing.publicKeys) ||
+		dealing.proof == nil || dealing.threshold > len(dealing.publicKeys) {
+		return false, fmt.Errorf("%w: inconsistent public dealing", ErrInvalidDealing)
+	}
+	if err := pvss.checkPublicShares(dealing); err != nil {
+		return false, err
+	}
+
+	return VerifyBatchDLEQ(pvss.group, NewTranscript(publicShareDomain), pvss.shareStatements(dealing), dealing.proof), nil
+}
+
+// checkPublicShares rejects identity public keys and encrypted shares. With
+// both at the identity a share's DLEQ statement holds for any response, so
+// the share would not be bound to the commitments.
+func (pvss *PedersenVSS) checkPublicShares(dealing *PublicDealing) error {
+	identity := pvss.group.Identity()
+	for i, key := range dealing.publicKeys {
+		if key.Equal(identity) {
+			return fmt.Errorf("%w: public key of participant %d is the identity", ErrInvalidDealing, i+1)
+		}
+		if dealing.encrypted[i].Equal(identity) {
+			return fmt.Errorf("%w: encrypted share of participant %d is the identity", ErrInvalidDealing, i+1)
+		}
+	}
+	return nil
+}
+
+// shareStatements returns, for each participant i, the statement that its
+// encrypted share Y_i holds the value committed to by X_i.
+func (pvss *PedersenVSS) shareStatements(dealing *PublicDealing) []DLEQStatement {
+	statements := make([]DLEQStatement, len(dealing.publicKeys))
+	for i, key := range dealing.publicKeys {
+		statements[i] = DLEQStatement{
+			G1: pvss.h, H1: pvss.evaluateCommitments(dealing.commitments, i+1),
+			G2: key, H2: dealing.encrypted[i],
+		}
+	}
+	return statements
+}
+
+// DecryptShare decrypts participant id's share of dealing with its key and
+// proves the decryption correct. The dealing is verified first; one whose
+// shares do not match the commitments gives ErrInvalidDealing.
+func (pvss *PedersenVSS) DecryptShare(dealing *PublicDealing, id int, key *ParticipantKey) (*DecryptedShare, error) {
+	if id < 1 || id > len(dealing.publicKeys) {
+		return nil, fmt.Errorf("invalid participant ID: %d", id)
+	}
+	publicKey := dealing.publicKeys[id-1]
+	if !publicKey.Equal(pvss.group.Generator().ScalarMult(key.Private)) {
+		return nil, fmt.Errorf("key does not belong to participant %d", id)
+	}
+	valid, err := pvss.VerifyPublicDealing(dealing)
+	if err != nil {
+		return nil, err
+	}
+	if !valid {
+		return nil, fmt.Errorf("%w: shares do not match the commitments", ErrInvalidDealing)
+	}
+
+	// S_i = Y_i·x⁻¹, and Y_i = S_i·x proves it
+	inverse := pvss.field.newScalar().Invert(key.Private)
+	share := &DecryptedShare{id: id, value: dealing.encrypted[id-1].ScalarMult(inverse)}
+
+	share.proof, err = ProveDLEQ(pvss.group, decryptionTranscript(id), pvss.decryptionStatement(dealing, share), key.Private)
+	if err != nil {
+		return nil, fmt.Errorf("failed to prove decryption: %v", err)
+	}
+
+	share.raw = pvss.serializeDecryptedShare(share)
+	return share, nil
+}
+
+// VerifyDecryptedShare checks the proof that share is the correct
+// decryption of its participant's encrypted share in dealing.
+func (pvss *PedersenVSS) VerifyDecryptedShare(dealing *PublicDealing, share *DecryptedShare) (bool, error) {
+	if share.id < 1 || share.id > len(dealing.publicKeys) {
+		return false, fmt.Errorf("invalid participant ID: %d", share.id)
+	}
+
+	return VerifyDLEQ(pvss.group, decryptionTranscript(share.id), pvss.decryptionStatement(dealing, share), share.proof), nil
+}
+
+// decryptionTranscript starts the transcript of participant id's
+// decryption proof.
+func decryptionTranscript(id int) *Transcript {
+	t := NewTranscript(publicDecryptionDomain)
+	t.AppendMessage("participant", appendCount(nil, id))
+	return t
+}
+
+// decryptionStatement claims log_g y_i = log_S_i Y_i: the participant's
+// private key takes its decrypted share to its encrypted share.
+func (pvss *PedersenVSS) decryptionStatement(dealing *PublicDealing, share *DecryptedShare) DLEQStatement {
+	return DLEQStatement{
+		G1: pvss.group.Generator(), H1: dealing.publicKeys[share.id-1],
+		G2: share.value, H2: dealing.encrypted[share.id-1],
+	}
+}
+
+// ReconstructPublic recovers the secret of dealing from at least threshold
+// decrypted shares. Every share's decryption proof is checked, and a share
+// that fails gives ErrShareMismatch.
+func (pvss *PedersenVSS) ReconstructPublic(dealing *PublicDealing, shares []*DecryptedShare) ([]byte, error) {
+	if len(shares) < dealing.threshold {
+		return nil, fmt.Errorf("insufficient shares: need %d, got %d", dealing.threshold, len(shares))
+	}
+
+	ids := make([]int, len(shares))
+	seen := make(map[int]bool)
+	for i, share := range shares {
+		if seen[share.id] {
+			return nil, fmt.Errorf("duplicate share ID: %d", share.id)
+		}
+		seen[share.id] = true
+
+		valid, err := pvss.VerifyDecryptedShare(dealing, share)
+		if err != nil {
+			return nil, err
+		}
+		if !valid {
+			return nil, fmt.Errorf("%w: decryption proof of share %d", ErrShareMismatch, share.id)
+		}
+		ids[i] = share.id
+	}
+
+	coefficients, err := pvss.lagrangeCoefficients(ids)
+	if err != nil {
+		return nil, err
+	}
+	key := pvss.group.Identity()
+	for i, share := range shares {
+		key = key.Add(share.value.ScalarMult(coefficients[i]))
+	}
+
+	gcm, err := newEnvelopeCipher(pvss.publicSecretKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if len(dealing.ciphertext) < gcm.NonceSize()+gcm.Overhead() {
+		return nil, fmt.Errorf("%w: ciphertext too short", ErrInvalidDealing)
+	}
+	nonce := dealing.ciphertext[:gcm.NonceSize()]
+
+	secret, err := gcm.Open(nil, nonce, dealing.ciphertext[gcm.NonceSize():], dealing.raw[:dealing.body])
+	if err != nil {
+		return nil, fmt.Errorf("%w: authentication failed", ErrInvalidDealing)
+	}
+
+	return secret, nil
+}
+
+// publicSecretKey derives the AES key sealing a dealing's secret from g·s.
+func (pvss *PedersenVSS) publicSecretKey(element Element) []byte {
+	sum := sha256.Sum256(append([]byte(publicSecretDomain), element.Bytes()...))
+	return sum[:]
+}
+
+// serializePublicDealingBody writes [header][threshold][share count]
+// [public keys][commitments][encrypted shares][challenge][responses],
+// everything but the ciphertext that follows it.
+func (pvss *PedersenVSS) serializePublicDealingBody(dealing *PublicDealing) []byte {
+	result := appendHeader(nil, publicMagic, pvss.group.ID(), schemePublic, 0)
+	result = appendCount(result, dealing.threshold)
+	result = appendCount(result, len(dealing.publicKeys))
+
+	for _, elements := range [][]Element{dealing.publicKeys, dealing.commitments, dealing.encrypted} {
+		for _, element := range elements {
+			result = append(result, element.Bytes()...)
+		}
+	}
+	result = appendFixedScalars(result, dealing.proof.challenge)
+	result = appendFixedScalars(result, dealing.proof.responses...)
+
+	return result
+}
+
+// ParsePublicDealing decodes a dealing produced by PublicDealing.Bytes.
+func (pvss *PedersenVSS) ParsePublicDealing(data []byte) (*PublicDealing, error) {
+	_, offset, err := readHeader(data, publicMagic, pvss.group.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	dealing := &PublicDealing{}
+	dealing.threshold, offset, err = readCount(data, offset)
+	if err != nil {
+		return nil, errors.New("insufficient public dealing data")
+	}
+	count, offset, err := readCount(data, offset)
+	if err != nil {
+		return nil, errors.New("insufficient public dealing data")
+	}
+	if dealing.threshold < 1 || dealing.threshold > count {
+		return nil, errors.New("invalid threshold or share count")
+	}
+
+	elementSize := pvss.group.ElementSize()
+	proofSize := (count + 1) * pvss.field.size
+	if len(data)-offset < (2*count+dealing.threshold)*elementSize+proofSize {
+		return nil, errors.New("insufficient public dealing data")
+	}
+
+	readElements := func(n int) ([]Element, error) {
+		elements := make([]Element, n)
+		for i := range elements {
+			element, err := pvss.group.DecodeElement(data[offset : offset+elementSize])
+			if err != nil {
+				return nil, fmt.Errorf("failed to deserialize element: %v", err)
+			}
+			elements[i] = element
+			offset += elementSize
+		}
+		return elements, nil
+	}
+	if dealing.publicKeys, err = readElements(count); err != nil {
+		return nil, err
+	}
+	if dealing.commitments, err = readElements(dealing.threshold); err != nil {
+		return nil, err
+	}
+	if dealing.encrypted, err = readElements(count); err != nil {
+		return nil, err
+	}
+	if err := pvss.checkPublicShares(dealing); err != nil {
+		return nil, err
+	}
+
+	scalars, err := readFixedScalars(pvss.group, data[offset:offset+proofSize], count+1)
+	if err != nil {
+		return nil, err
+	}
+	dealing.proof = &BatchDLEQProof{challenge: scalars[0], responses: scalars[1:]}
+	offset += proofSize
+
+	dealing.body = offset
+	dealing.ciphertext = append([]byte{}, data[offset:]...)
+	dealing.raw = append([]byte{}, data...)
+
+	return dealing, nil
+}
+
+// serializeDecryptedShare writes [header][ID][value][proof].
+func (pvss *PedersenVSS) serializeDecryptedShare(share *DecryptedShare) []byte {
+	result := appendHeader(nil, decryptMagic, pvss.group.ID(), schemePublic, 0)
+	result = appendCount(result, share.id)
+	result = append(result, share.value.Bytes()...)
+
+	return append(result, share.proof.Bytes()...)
+}
+
+// ParseDecryptedShare decodes a share produced by DecryptedShare.Bytes.
+func (pvss *PedersenVSS) ParseDecryptedShare(data []byte) (*DecryptedShare, error) {
+	_, offset, err := readHeader(data, decryptMagic, pvss.group.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	share := &DecryptedShare{}
+	share.id, offset, err = readCount(data, offset)
+	if err != nil {
+		return nil, errors.New("insufficient decrypted share data")
+	}
+
+	elementSize := pvss.group.ElementSize()
+	if offset+elementSize > len(data) {
+		return nil, errors.New("insufficient decrypted share data")
+	}
+	share.value, err = pvss.group.DecodeElement(data[offset : offset+elementSize])
+	if err != nil {
+		return nil, fmt.Errorf("failed to deserialize element: %v", err)
+	}
+	offset += elementSize
+
+	share.proof, err = ParseDLEQProof(pvss.group, data[offset:])
+	if err != nil {
+		return nil, err
+	}
+
+	share.raw = append([]byte{}, data...)
+	return share, nil
+}
@@ -0,0 +1,289 @@
+package pvss
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+// publicParticipants generates n participant keys
+func publicParticipants(t *testing.T, pvss *PedersenVSS, n int) ([]*ParticipantKey, []Element) {
+	t.Helper()
+
+	keys := make([]*ParticipantKey, n)
+	publicKeys := make([]Element, n)
+	for i := range keys {
+		key, err := pvss.GenerateParticipantKey()
+		if err != nil {
+			t.Fatalf("GenerateParticipantKey failed: %v", err)
+		}
+		keys[i], publicKeys[i] = key, key.Public
+	}
+	return keys, publicKeys
+}
+
+// TestPublicDealing tests dealing, public verification, decryption and
+// reconstruction, with every message passed through its encoding
+func TestPublicDealing(t *testing.T) {
+	secret := []byte("audited without holding a share")
+
+	for _, group := range allGroups() {
+		t.Run(group.Name(), func(t *testing.T) {
+			pvss := NewPedersenVSS(WithGroup(group))
+			keys, publicKeys := publicParticipants(t, pvss, 5)
+
+			dealt, err := pvss.DealPublic(secret, publicKeys, 3)
+			if err != nil {
+				t.Fatalf("DealPublic failed: %v", err)
+			}
+			dealing, err := pvss.ParsePublicDealing(dealt.Bytes())
+			if err != nil {
+				t.Fatalf("ParsePublicDealing failed: %v", err)
+			}
+			if dealing.Threshold() != 3 || len(dealing.PublicKeys()) != 5 {
+				t.Errorf("unexpected layout: threshold %d, %d keys", dealing.Threshold(), len(dealing.PublicKeys()))
+			}
+
+			// An auditor needs nothing but the dealing
+			valid, err := pvss.VerifyPublicDealing(dealing)
+			if err != nil || !valid {
+				t.Fatalf("VerifyPublicDealing: got %v, %v", valid, err)
+			}
+
+			var shares []*DecryptedShare
+			for _, id := range []int{5, 2, 4} {
+				share, err := pvss.DecryptShare(dealing, id, keys[id-1])
+				if err != nil {
+					t.Fatalf("DecryptShare %d failed: %v", id, err)
+				}
+				parsed, err := pvss.ParseDecryptedShare(share.Bytes())
+				if err != nil {
+					t.Fatalf("ParseDecryptedShare failed: %v", err)
+				}
+				if valid, err := pvss.VerifyDecryptedShare(dealing, parsed); err != nil || !valid {
+					t.Errorf("share %d: VerifyDecryptedShare got %v, %v", id, valid, err)
+				}
+				shares = append(shares, parsed)
+			}
+
+			reconstructed, err := pvss.ReconstructPublic(dealing, shares)
+			if err != nil {
+				t.Fatalf("ReconstructPublic failed: %v", err)
+			}
+			if !bytes.Equal(reconstructed, secret) {
+				t.Errorf("expected %q, got %q", secret, reconstructed)
+			}
+
+			if _, err := pvss.ReconstructPublic(dealing, shares[:2]); err == nil {
+				t.Error("expected error for fewer shares than the threshold")
+			}
+		})
+	}
+}
+
+// TestPublicDealing_Tampered tests that auditors and participants catch
+// encrypted shares that do not match the commitments
+func TestPublicDealing_Tampered(t *testing.T) {
+	pvss := NewPedersenVSS()
+	keys, publicKeys := publicParticipants(t, pvss, 4)
+
+	dealing, err := pvss.DealPublic([]byte("tampered"), publicKeys, 2)
+	if err != nil {
+		t.Fatalf("DealPublic failed: %v", err)
+	}
+
+	swapped := *dealing
+	swapped.encrypted = append([]Element{}, dealing.encrypted...)
+	swapped.encrypted[0], swapped.encrypted[1] = swapped.encrypted[1], swapped.encrypted[0]
+
+	if valid, err := pvss.VerifyPublicDealing(&swapped); err != nil || valid {
+		t.Errorf("swapped shares: got %v, %v", valid, err)
+	}
+	if _, err := pvss.DecryptShare(&swapped, 1, keys[0]); !errors.Is(err, ErrInvalidDealing) {
+		t.Errorf("expected ErrInvalidDealing, got %v", err)
+	}
+
+	forged := *dealing
+	responses := append([]*Scalar{}, dealing.proof.responses...)
+	responses[3] = NewScalar(P256()).Add(responses[3], testScalar(1))
+	forged.proof = &BatchDLEQProof{challenge: dealing.proof.challenge, responses: responses}
+	if valid, err := pvss.VerifyPublicDealing(&forged); err != nil || valid {
+		t.Errorf("forged proof: got %v, %v", valid, err)
+	}
+
+	// A response for one share does not carry over to another
+	moved := *dealing
+	responses = append([]*Scalar{}, dealing.proof.responses...)
+	responses[2] = responses[3]
+	moved.proof = &BatchDLEQProof{challenge: dealing.proof.challenge, responses: responses}
+	if valid, _ := pvss.VerifyPublicDealing(&moved); valid {
+		t.Error("moved proof verified")
+	}
+}
+
+// TestPublicDealing_IdentityKey tests that a dealing with an identity
+// public key and encrypted share is rejected, even though its proof holds
+// for that slot whatever the share
+func TestPublicDealing_IdentityKey(t *testing.T) {
+	pvss := NewPedersenVSS()
+	_, publicKeys := publicParticipants(t, pvss, 3)
+	identity := pvss.Group().Identity()
+
+	value, err := RandomScalar(pvss.Group())
+	if err != nil {
+		t.Fatalf("RandomScalar failed: %v", err)
+	}
+	coefficients, err := pvss.generateRandomPolynomial(value, 2)
+	if err != nil {
+		t.Fatalf("generateRandomPolynomial failed: %v", err)
+	}
+
+	dealing := &PublicDealing{
+		threshold:   2,
+		publicKeys:  []Element{publicKeys[0], identity, publicKeys[2]},
+		commitments: make([]Element, 2),
+		encrypted:   make([]Element, 3),
+	}
+	for j, coefficient := range coefficients {
+		dealing.commitments[j] = pvss.h.ScalarMult(coefficient)
+	}
+	shares := make([]*Scalar, 3)
+	for i, key := range dealing.publicKeys {
+		shares[i] = pvss.evaluatePolynomial(coefficients, i+1)
+		dealing.encrypted[i] = key.ScalarMult(shares[i])
+	}
+	if !dealing.encrypted[1].Equal(identity) {
+		t.Fatal("expected an identity encrypted share")
+	}
+
+	dealing.proof, err = ProveBatchDLEQ(pvss.group, NewTranscript(publicShareDomain), pvss.shareStatements(dealing), shares)
+	if err != nil {
+		t.Fatalf("ProveBatchDLEQ failed: %v", err)
+	}
+	if !VerifyBatchDLEQ(pvss.group, NewTranscript(publicShareDomain), pvss.shareStatements(dealing), dealing.proof) {
+		t.Fatal("expected the proof itself to verify")
+	}
+
+	if valid, err := pvss.VerifyPublicDealing(dealing); valid || !errors.Is(err, ErrInvalidDealing) {
+		t.Errorf("VerifyPublicDealing: got %v, %v", valid, err)
+	}
+	if _, err := pvss.ParsePublicDealing(pvss.serializePublicDealingBody(dealing)); !errors.Is(err, ErrInvalidDealing) {
+		t.Errorf("ParsePublicDealing: expected ErrInvalidDealing, got %v", err)
+	}
+}
+
+// TestDecryptedShare_Rejects tests that wrong decryptions and keys are
+// refused
+func TestDecryptedShare_Rejects(t *testing.T) {
+	pvss := NewPedersenVSS()
+	keys, publicKeys := publicParticipants(t, pvss, 3)
+
+	dealing, err := pvss.DealPublic([]byte("decryption proofs"), publicKeys, 2)
+	if err != nil {
+		t.Fatalf("DealPublic failed: %v", err)
+	}
+
+	if _, err := pvss.DecryptShare(dealing, 1, keys[1]); err == nil {
+		t.Error("expected error for another participant's key")
+	}
+	if _, err := pvss.DecryptShare(dealing, 4, keys[0]); err == nil {
+		t.Error("expected error for an unknown participant")
+	}
+
+	s1, err := pvss.DecryptShare(dealing, 1, keys[0])
+	if err != nil {
+		t.Fatalf("DecryptShare failed: %v", err)
+	}
+	s2, err := pvss.DecryptShare(dealing, 2, keys[1])
+	if err != nil {
+		t.Fatalf("DecryptShare failed: %v", err)
+	}
+
+	wrong := *s2
+	wrong.value = s2.value.Add(pvss.Group().Generator())
+	if valid, err := pvss.VerifyDecryptedShare(dealing, &wrong); err != nil || valid {
+		t.Errorf("wrong decryption: got %v, %v", valid, err)
+	}
+	if _, err := pvss.ReconstructPublic(dealing, []*DecryptedShare{s1, &wrong}); !errors.Is(err, ErrShareMismatch) {
+		t.Errorf("expected ErrShareMismatch, got %v", err)
+	}
+	if _, err := pvss.ReconstructPublic(dealing, []*DecryptedShare{s1, s1}); err == nil {
+		t.Error("expected error for duplicate shares")
+	}
+
+	// The ciphertext is bound to the rest of the dealing
+	other, err := pvss.DealPublic([]byte("decryption proofs"), publicKeys, 2)
+	if err != nil {
+		t.Fatalf("DealPublic failed: %v", err)
+	}
+	mixed := *dealing
+	mixed.ciphertext = other.ciphertext
+	if _, err := pvss.ReconstructPublic(&mixed, []*DecryptedShare{s1, s2}); !errors.Is(err, ErrInvalidDealing) {
+		t.Errorf("expected ErrInvalidDealing, got %v", err)
+	}
+}
+
+// TestPublicDealing_Invalid tests parameter validation and malformed
+// encodings
+func TestPublicDealing_Invalid(t *testing.T) {
+	pvss := NewPedersenVSS()
+	keys, publicKeys := publicParticipants(t, pvss, 3)
+
+	if _, err := pvss.DealPublic([]byte("x"), publicKeys, 4); err == nil {
+		t.Error("expected error for threshold above the participants")
+	}
+	if _, err := pvss.DealPublic(nil, publicKeys, 2); err == nil {
+		t.Error("expected error for empty secret")
+	}
+	if _, err := pvss.DealPublic([]byte("x"), []Element{publicKeys[0], pvss.Group().Identity()}, 2); err == nil {
+		t.Error("expected error for identity public key")
+	}
+
+	dealing, err := pvss.DealPublic([]byte("x"), publicKeys, 2)
+	if err != nil {
+		t.Fatalf("DealPublic failed: %v", err)
+	}
+	encoded := dealing.Bytes()
+	if _, err := pvss.ParsePublicDealing(encoded[:dealing.body-1]); err == nil {
+		t.Error("expected error for truncated dealing")
+	}
+	if encoded[3] != byte(schemePublic) {
+		t.Errorf("dealing scheme = %d, want %d", encoded[3], schemePublic)
+	}
+	feldman := append([]byte{}, encoded...)
+	feldman[3] = byte(SchemeFeldman)
+	if _, err := pvss.ParsePublicDealing(feldman); err == nil {
+		t.Error("expected error for a dealing with a SplitSecret scheme")
+	}
+
+	share, err := pvss.DecryptShare(dealing, 3, keys[2])
+	if err != nil {
+		t.Fatalf("DecryptShare failed: %v", err)
+	}
+	encoded = share.Bytes()
+	if _, err := pvss.ParseDecryptedShare(encoded[:len(encoded)-1]); err == nil {
+		t.Error("expected error for truncated share")
+	}
+	if _, err := pvss.ParseDecryptedShare(append(encoded, 0)); err == nil {
+		t.Error("expected error for trailing data")
+	}
+	pedersen := append([]byte{}, encoded...)
+	pedersen[3] = byte(SchemePedersen)
+	if _, err := pvss.ParseDecryptedShare(pedersen); err == nil {
+		t.Error("expected error for a share with a SplitSecret scheme")
+	}
+
+	// No other payload may claim the public scheme
+	shares, err := pvss.SplitSecret("x", 3, 2)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+	meta, err := pvss.decodeMetadataBytes(shares[0].KeyCheck)
+	if err != nil {
+		t.Fatalf("decodeMetadataBytes failed: %v", err)
+	}
+	meta[3] = byte(schemePublic)
+	if _, err := pvss.deserializeMetadata(meta); err == nil {
+		t.Error("expected error for a KeyCheck with the public scheme")
+	}
+}
@@ -1,88 +1,222 @@
 package pvss
 
 import (
-	"crypto/elliptic"
-	"crypto/rand"
 	"errors"
 	"fmt"
-	"math/big"
+	"time"
 )
 
+// pedersenGeneratorSeed returns the domain separation tag hashed to derive
+// the second Pedersen generator H of a group. Nobody knows log_g(H).
+func pedersenGeneratorSeed(group Group) []byte {
+	return []byte("pvss/pedersen/" + group.Name() + "/H")
+}
+
 type Share struct {
 	Key      string // Mnemonic for share data
 	KeyCheck string // Mnemonic for verification data only
 }
 
-// Point represents a point on an elliptic curve
-type Point struct {
-	X *big.Int
-	Y *big.Int
+// CommitmentScheme selects how polynomial coefficients are committed to in
+// the share metadata.
+type CommitmentScheme byte
+
+const (
+	// SchemeFeldman commits to each coefficient as g^a_i. Commitments are
+	// half the work to verify but reveal g^secret for every chunk.
+	SchemeFeldman CommitmentScheme = 1
+	// SchemePedersen commits to each coefficient as g^a_i·h^b_i using a
+	// random blinding polynomial, hiding the secret information-theoretically.
+	SchemePedersen CommitmentScheme = 2
+
+	// schemePublic marks publicly verifiable dealings and their decrypted
+	// shares, whose coefficients are committed to as h^a_i alone.
+	schemePublic CommitmentScheme = 3
+)
+
+func (scheme CommitmentScheme) String() string {
+	switch scheme {
+	case SchemeFeldman:
+		return "feldman"
+	case SchemePedersen:
+		return "pedersen"
+	case schemePublic:
+		return "public"
+	default:
+		return fmt.Sprintf("unknown(%d)", byte(scheme))
+	}
+}
+
+func (scheme CommitmentScheme) valid() bool {
+	return scheme == SchemeFeldman || scheme == SchemePedersen
+}
+
+// SplitOption configures a call to SplitSecret.
+type SplitOption func(*splitOptions)
+
+type splitOptions struct {
+	scheme CommitmentScheme
+}
+
+func defaultSplitOptions() splitOptions {
+	return splitOptions{scheme: SchemePedersen}
+}
+
+// WithCommitmentScheme selects the commitment scheme written into the share
+// metadata. The default is SchemePedersen.
+func WithCommitmentScheme(scheme CommitmentScheme) SplitOption {
+	return func(opts *splitOptions) {
+		opts.scheme = scheme
+	}
+}
+
+// metadata is the verification data shared by every share of a split.
+type metadata struct {
+	set         ShareSetID
+	scheme      CommitmentScheme
+	threshold   int
+	chunkCount  int
+	commitments [][]Element
+	wholeScalar bool // The single chunk is a whole scalar, not secret bytes
+}
+
+// shareData is the private part of a single share.
+type shareData struct {
+	id          int
+	set         ShareSetID // Split this share belongs to
+	sizes       []int      // Original byte length of each chunk
+	values      []*Scalar  // Share value for each chunk
+	blindings   []*Scalar  // Blinding share for each chunk, nil under Feldman
+	wholeScalar bool       // Split marked as whole scalars, see metadata
 }
 
 type PedersenVSS struct {
-	curve           elliptic.Curve
-	order           *big.Int
+	group           Group
+	field           *scalarField
+	h               Element // Second generator for the blinding term
 	mnemonicEncoder *MnemonicEncoder
 }
 
-func NewPedersenVSS() *PedersenVSS {
-	curve := elliptic.P256()
+// PedersenOption configures a PedersenVSS.
+type PedersenOption func(*PedersenVSS)
 
-	return &PedersenVSS{
-		curve:           curve,
-		order:           curve.Params().N,
+// WithMnemonicEncoder sets the encoder new shares are written with, for
+// example NewBIP39Encoder(LanguageJapanese). The default is English. Shares
+// in any BIP-39 language are read regardless of this choice.
+func WithMnemonicEncoder(encoder *MnemonicEncoder) PedersenOption {
+	return func(pvss *PedersenVSS) {
+		pvss.mnemonicEncoder = encoder
+	}
+}
+
+// WithGroup selects the group commitments are computed in. The default is
+// P256. Shares can only be verified and reconstructed by a PedersenVSS
+// using the group they were split with.
+func WithGroup(group Group) PedersenOption {
+	return func(pvss *PedersenVSS) {
+		pvss.group = group
+	}
+}
+
+func NewPedersenVSS(opts ...PedersenOption) *PedersenVSS {
+	pvss := &PedersenVSS{
+		group:           P256(),
 		mnemonicEncoder: NewMnemonicEncoder(BIP39EnglishWords()),
 	}
+	for _, opt := range opts {
+		opt(pvss)
+	}
+	pvss.field = scalarFieldOf(pvss.group)
+	pvss.h = pvss.group.HashToElement(pedersenGeneratorSeed(pvss.group))
+
+	return pvss
+}
+
+// Group returns the group commitments are computed in.
+func (pvss *PedersenVSS) Group() Group {
+	return pvss.group
+}
+
+// chunkSize is the number of secret bytes carried by each chunk, the
+// largest that always fits below the group order.
+func (pvss *PedersenVSS) chunkSize() int {
+	return groupChunkSize(pvss.group)
+}
+
+// unknownChunkSize stands in for the size of each chunk of a share written
+// before chunk sizes were recorded.
+const unknownChunkSize = -1
+
+// maxChunkLength is the longest chunk a share may record. Split secrets use
+// at most chunkSize bytes per chunk; only a split marked as whole scalars,
+// such as a key generated without a dealer, records the full scalar width.
+func (pvss *PedersenVSS) maxChunkLength(wholeScalar bool) int {
+	if wholeScalar {
+		return pvss.field.size
+	}
+	return pvss.chunkSize()
 }
 
-func (pvss *PedersenVSS) chunkSecret(secret string) [][]byte {
-	secretBytes := []byte(secret)
-	// Use 31 bytes to ensure we stay well within P-256 field size
-	chunkSize := 31
+func (pvss *PedersenVSS) chunkSecret(secret []byte) [][]byte {
+	chunkSize := pvss.chunkSize()
 
 	var chunks [][]byte
-	for i := 0; i < len(secretBytes); i += chunkSize {
+	for i := 0; i < len(secret); i += chunkSize {
 		end := i + chunkSize
-		if end > len(secretBytes) {
-			end = len(secretBytes)
+		if end > len(secret) {
+			end = len(secret)
 		}
 		chunk := make([]byte, end-i)
-		copy(chunk, secretBytes[i:end])
+		copy(chunk, secret[i:end])
 		chunks = append(chunks, chunk)
 	}
 
 	return chunks
 }
 
-func (pvss *PedersenVSS) chunkToSecret(chunk []byte) *big.Int {
-	if len(chunk) == 0 {
-		return big.NewInt(0)
-	}
-
-	secretInt := new(big.Int).SetBytes(chunk)
+// chunkToSecret converts a chunk to a scalar. Chunks are always shorter
+// than the order, so the value needs no reduction.
+func (pvss *PedersenVSS) chunkToSecret(chunk []byte) *Scalar {
+	padded := make([]byte, pvss.field.size)
+	copy(padded[len(padded)-len(chunk):], chunk)
 
-	// Ensure it's within field order (should be safe with 31-byte chunks)
-	if secretInt.Cmp(pvss.order) >= 0 {
-		secretInt.Mod(secretInt, pvss.order)
+	secret, err := pvss.field.newScalar().SetBytes(padded)
+	if err != nil {
+		panic("pvss: chunk exceeds the group order")
 	}
 
-	return secretInt
+	return secret
 }
 
-func (pvss *PedersenVSS) secretToChunk(secretInt *big.Int) []byte {
-	return secretInt.Bytes()
+// secretToChunk converts a reconstructed chunk secret back into exactly size
+// bytes, restoring any leading zero bytes of the original chunk.
+func (pvss *PedersenVSS) secretToChunk(secret *Scalar, size int) ([]byte, error) {
+	encoded := secret.Bytes()
+	if size == unknownChunkSize {
+		// Shares written before chunk sizes were recorded reconstruct
+		// without leading zero bytes, as they always did
+		for len(encoded) > 0 && encoded[0] == 0 {
+			encoded = encoded[1:]
+		}
+		return encoded, nil
+	}
+	if size > len(encoded) || !isZero(encoded[:len(encoded)-size]) {
+		return nil, fmt.Errorf("reconstructed chunk does not fit in %d bytes", size)
+	}
+
+	return encoded[len(encoded)-size:], nil
 }
 
-func (pvss *PedersenVSS) generateRandomPolynomial(secret *big.Int, threshold int) ([]*big.Int, error) {
+func (pvss *PedersenVSS) generateRandomPolynomial(secret *Scalar, threshold int) ([]*Scalar, error) {
 	if threshold < 1 {
 		return nil, errors.New("threshold must be at least 1")
 	}
 
-	coefficients := make([]*big.Int, threshold)
-	coefficients[0] = new(big.Int).Set(secret)
+	coefficients := make([]*Scalar, threshold)
+	coefficients[0] = pvss.field.newScalar().Set(secret)
 
 	for i := 1; i < threshold; i++ {
-		coeff, err := rand.Int(rand.Reader, pvss.order)
+		coeff, err := pvss.field.random()
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate random coefficient: %v", err)
 		}
@@ -92,219 +226,542 @@ func (pvss *PedersenVSS) generateRandomPolynomial(secret *big.Int, threshold int
 	return coefficients, nil
 }
 
-func (pvss *PedersenVSS) evaluatePolynomial(coefficients []*big.Int, x int) *big.Int {
-	if len(coefficients) == 0 {
-		return big.NewInt(0)
-	}
-
-	result := new(big.Int).Set(coefficients[0])
-	if len(coefficients) == 1 {
-		return result
-	}
-
-	xBig := big.NewInt(int64(x))
-	xPower := new(big.Int).Set(xBig)
-
-	for i := 1; i < len(coefficients); i++ {
-		term := new(big.Int).Mul(coefficients[i], xPower)
-		term.Mod(term, pvss.order)
+func (pvss *PedersenVSS) randomScalar() (*Scalar, error) {
+	return pvss.field.random()
+}
 
-		result.Add(result, term)
-		result.Mod(result, pvss.order)
+// evaluatePolynomial evaluates the polynomial at x by Horner's rule.
+func (pvss *PedersenVSS) evaluatePolynomial(coefficients []*Scalar, x int) *Scalar {
+	result := pvss.field.newScalar()
+	xScalar := pvss.field.newScalar().SetUint64(uint64(x))
 
-		if i < len(coefficients)-1 {
-			xPower.Mul(xPower, xBig)
-			xPower.Mod(xPower, pvss.order)
-		}
+	for i := len(coefficients) - 1; i >= 0; i-- {
+		result.Multiply(result, xScalar).Add(result, coefficients[i])
 	}
 
 	return result
 }
 
-func (pvss *PedersenVSS) generateCommitments(coefficients []*big.Int) ([]Point, error) {
-	commitments := make([]Point, len(coefficients))
+// generateCommitments computes the Pedersen commitments g^a_i·h^b_i for the
+// secret polynomial coefficients a_i and blinding coefficients b_i. With nil
+// blindings it computes the Feldman commitments g^a_i instead.
+func (pvss *PedersenVSS) generateCommitments(coefficients, blindings []*Scalar) ([]Element, error) {
+	if blindings != nil && len(coefficients) != len(blindings) {
+		return nil, errors.New("mismatched coefficients and blinding coefficients")
+	}
+
+	commitments := make([]Element, len(coefficients))
 
 	for i, coeff := range coefficients {
-		x, y := pvss.curve.ScalarBaseMult(coeff.Bytes())
-		if x == nil || y == nil {
-			return nil, fmt.Errorf("failed to generate commitment %d", i)
+		var blinding *Scalar
+		if blindings != nil {
+			blinding = blindings[i]
 		}
-		commitments[i] = Point{X: new(big.Int).Set(x), Y: new(big.Int).Set(y)}
+
+		commitments[i] = pvss.commit(coeff, blinding)
 	}
 
 	return commitments, nil
 }
 
-func (pvss *PedersenVSS) serializeCommitment(point Point) []byte {
-	// Use compressed point format: 1 byte for parity + 32 bytes for X coordinate
-	result := make([]byte, 33)
-
-	xBytes := point.X.Bytes()
-	if len(xBytes) <= 32 {
-		copy(result[33-len(xBytes):], xBytes)
-	} else {
-		copy(result[1:], xBytes[len(xBytes)-32:])
+// commit returns g^value·h^blinding, or g^value when blinding is nil.
+func (pvss *PedersenVSS) commit(value, blinding *Scalar) Element {
+	commitment := pvss.group.Generator().ScalarMult(value)
+	if blinding == nil {
+		return commitment
 	}
 
-	// Store Y parity in first byte (0x02 for even Y, 0x03 for odd Y)
-	if point.Y.Bit(0) == 0 {
-		result[0] = 0x02
-	} else {
-		result[0] = 0x03
+	return commitment.Add(pvss.h.ScalarMult(blinding))
+}
+
+// evaluateCommitments computes Π C_i^(x^i), the commitment to the share at x.
+func (pvss *PedersenVSS) evaluateCommitments(commitments []Element, x int) Element {
+	result := pvss.group.Identity()
+	xScalar := pvss.field.newScalar().SetUint64(uint64(x))
+	xPower := pvss.field.newScalar().SetUint64(1)
+
+	for i, commitment := range commitments {
+		// Multiply commitment by x^i and add to running sum
+		result = result.Add(commitment.ScalarMult(xPower))
+
+		// Update x power for next iteration
+		if i < len(commitments)-1 {
+			xPower.Multiply(xPower, xScalar)
+		}
 	}
 
 	return result
 }
 
-func (pvss *PedersenVSS) deserializeCommitment(data []byte) (Point, error) {
-	if len(data) != 33 {
-		return Point{}, errors.New("invalid commitment data length")
+func isZero(data []byte) bool {
+	for _, b := range data {
+		if b != 0 {
+			return false
+		}
 	}
+	return true
+}
 
-	parity := data[0]
-	if parity != 0x02 && parity != 0x03 {
-		return Point{}, errors.New("invalid parity byte")
+func (pvss *PedersenVSS) serializeShareData(share shareData) []byte {
+	scheme := SchemePedersen
+	if share.blindings == nil {
+		scheme = SchemeFeldman
 	}
+	result := appendHeader(nil, shareMagic, pvss.group.ID(), scheme, scalarFlags(share.wholeScalar))
 
-	xBytes := data[1:]
-	x := new(big.Int).SetBytes(xBytes)
+	// ID, chunk count, share set ID
+	result = appendCount(result, share.id)
+	result = appendCount(result, len(share.values))
+	result = appendShareSetID(result, share.set)
 
-	// Reconstruct Y coordinate using curve equation: y² = x³ - 3x + b
-	params := pvss.curve.Params()
+	if len(share.values) == 0 {
+		return result
+	}
 
-	// Compute x³ - 3x + b
-	x3 := new(big.Int).Mul(x, x)
-	x3.Mul(x3, x)
-	x3.Mod(x3, params.P)
+	// Original byte length of every chunk
+	for _, size := range share.sizes {
+		result = append(result, byte(size))
+	}
 
-	threeX := new(big.Int).Mul(big.NewInt(3), x)
-	threeX.Mod(threeX, params.P)
+	// Serialize each value with its actual length, followed by the blinding
+	// values in the same layout
+	result = appendScalars(result, share.values)
+	result = appendScalars(result, share.blindings)
 
-	ySquared := new(big.Int).Sub(x3, threeX)
-	ySquared.Add(ySquared, params.B)
-	ySquared.Mod(ySquared, params.P)
+	return result
+}
 
-	y := new(big.Int).ModSqrt(ySquared, params.P)
-	if y == nil {
-		return Point{}, errors.New("point not on curve")
+// appendScalars writes each scalar as a length byte followed by its
+// big-endian encoding without leading zero bytes.
+func appendScalars(dst []byte, scalars []*Scalar) []byte {
+	for _, scalar := range scalars {
+		scalarBytes := scalar.Bytes()
+		for len(scalarBytes) > 0 && scalarBytes[0] == 0 {
+			scalarBytes = scalarBytes[1:]
+		}
+		dst = append(dst, byte(len(scalarBytes)))
+		dst = append(dst, scalarBytes...)
 	}
+	return dst
+}
 
-	// Choose correct Y based on parity
-	if (y.Bit(0) == 0) != (parity == 0x02) {
-		y.Sub(params.P, y)
+// deserializeShareData parses share data with or without a format header.
+// A share written before the header existed starts with its ID, so one whose
+// ID equals shareMagic looks headered; if it does not parse as headered, it
+// is read without a header before the header's error is reported.
+func (pvss *PedersenVSS) deserializeShareData(data []byte) (shareData, error) {
+	if len(data) == 0 || data[0] != shareMagic {
+		if err := pvss.checkHeaderless(); err != nil {
+			return shareData{}, err
+		}
+		return pvss.deserializeHeaderlessShare(data)
 	}
 
-	return Point{X: x, Y: y}, nil
+	share, err := pvss.deserializeHeaderedShare(data)
+	if err != nil && pvss.checkHeaderless() == nil {
+		if legacy, legacyErr := pvss.deserializeHeaderlessShare(data); legacyErr == nil {
+			return legacy, nil
+		}
+	}
+	return share, err
 }
 
-func (pvss *PedersenVSS) serializeShareData(id int, values []*big.Int) []byte {
-	if len(values) == 0 {
-		return []byte{byte(id), 0}
+// deserializeHeaderedShare parses share data that starts with a format
+// header.
+func (pvss *PedersenVSS) deserializeHeaderedShare(data []byte) (shareData, error) {
+	header, offset, err := readHeader(data, shareMagic, pvss.group.ID())
+	if err != nil {
+		return shareData{}, err
 	}
 
-	// Header: 1 byte for ID, 1 byte for chunk count
-	result := []byte{byte(id), byte(len(values))}
-
-	// Serialize each value with its actual length
-	for _, value := range values {
-		valueBytes := value.Bytes()
-		result = append(result, byte(len(valueBytes)))
-		result = append(result, valueBytes...)
+	share, err := pvss.deserializeShareBody(data, offset, header)
+	if err != nil {
+		return shareData{}, err
+	}
+	if len(share.values) > 0 && (share.blindings != nil) != (header.scheme == SchemePedersen) {
+		return shareData{}, fmt.Errorf("share blinding values do not match the %v scheme", header.scheme)
 	}
 
-	return result
+	return share, nil
 }
 
-func (pvss *PedersenVSS) deserializeShareData(data []byte) (int, []*big.Int, error) {
+// deserializeHeaderlessShare parses a share written before the header
+// existed: [id][chunk count] followed by each value in appendScalars layout.
+// Such shares have no share set, no blinding values and no record of their
+// chunk sizes.
+func (pvss *PedersenVSS) deserializeHeaderlessShare(data []byte) (shareData, error) {
 	if len(data) < 2 {
-		return 0, nil, errors.New("insufficient share data")
+		return shareData{}, errors.New("insufficient share data")
 	}
 
-	id := int(data[0])
+	share := shareData{id: int(data[0])}
 	chunkCount := int(data[1])
+	if chunkCount == 0 {
+		return share, nil
+	}
+
+	values, offset, err := pvss.readScalars(data, 2, chunkCount)
+	if err != nil {
+		return shareData{}, err
+	}
+	if offset != len(data) {
+		return shareData{}, errors.New("trailing share data")
+	}
+	share.values = values
+
+	share.sizes = make([]int, chunkCount)
+	for i := range share.sizes {
+		share.sizes[i] = unknownChunkSize
+	}
+
+	return share, nil
+}
+
+// checkHeaderless rejects headerless payloads unless this PedersenVSS uses
+// P-256, the only group they were ever written over.
+func (pvss *PedersenVSS) checkHeaderless() error {
+	if pvss.group.ID() != curveP256 {
+		return fmt.Errorf("%w: payload has no header and predates %s support", ErrUnsupportedCurve, pvss.group.Name())
+	}
+	return nil
+}
+
+// deserializeShareBody parses the share fields that follow header:
+// [id][chunk count][share set ID][chunk sizes][values][blindings].
+func (pvss *PedersenVSS) deserializeShareBody(data []byte, offset int, header formatHeader) (shareData, error) {
+	id, offset, err := readCount(data, offset)
+	if err != nil {
+		return shareData{}, errors.New("insufficient share data")
+	}
+	chunkCount, offset, err := readCount(data, offset)
+	if err != nil {
+		return shareData{}, errors.New("insufficient share data")
+	}
+
+	share := shareData{id: id, wholeScalar: header.flags&flagWholeScalar != 0}
+
+	set, offset, err := readShareSetID(data, offset)
+	if err != nil {
+		return shareData{}, err
+	}
+	share.set = set
 
 	if chunkCount == 0 {
-		return id, nil, nil
+		return share, nil
+	}
+
+	if len(data) < offset+chunkCount {
+		return shareData{}, errors.New("insufficient chunk length data")
+	}
+
+	share.sizes = make([]int, chunkCount)
+	for i := range share.sizes {
+		share.sizes[i] = int(data[offset+i])
+		if limit := pvss.maxChunkLength(share.wholeScalar); share.sizes[i] > limit {
+			return shareData{}, fmt.Errorf("chunk %d length %d exceeds %d bytes", i, share.sizes[i], limit)
+		}
 	}
 
-	values := make([]*big.Int, chunkCount)
-	offset := 2
+	values, offset, err := pvss.readScalars(data, offset+chunkCount, chunkCount)
+	if err != nil {
+		return shareData{}, err
+	}
+	share.values = values
+
+	// Shares without blinding values carry nothing after the share values
+	if offset == len(data) {
+		return share, nil
+	}
+
+	blindings, offset, err := pvss.readScalars(data, offset, chunkCount)
+	if err != nil {
+		return shareData{}, err
+	}
+
+	if offset != len(data) {
+		return shareData{}, errors.New("trailing share data")
+	}
+	share.blindings = blindings
+
+	return share, nil
+}
+
+// decodeShareData verifies and decodes a Key phrase.
+func (pvss *PedersenVSS) decodeShareData(key string) (shareData, error) {
+	shareDataBytes, err := pvss.decodePhrase(key, shareMagic, "share")
+	if err != nil {
+		return shareData{}, err
+	}
+
+	share, err := pvss.deserializeShareData(shareDataBytes)
+	if err != nil {
+		return shareData{}, fmt.Errorf("failed to parse share data: %w", err)
+	}
 
-	for i := 0; i < chunkCount; i++ {
+	return share, nil
+}
+
+// readScalars parses count scalars written by appendScalars, rejecting
+// values that are not below the group order.
+func (pvss *PedersenVSS) readScalars(data []byte, offset, count int) ([]*Scalar, int, error) {
+	scalars := make([]*Scalar, count)
+
+	for i := 0; i < count; i++ {
 		if offset >= len(data) {
-			return 0, nil, errors.New("insufficient value length data")
+			return nil, 0, errors.New("insufficient value length data")
 		}
 
 		valueLen := int(data[offset])
 		offset++
 
 		if offset+valueLen > len(data) {
-			return 0, nil, errors.New("insufficient value data")
+			return nil, 0, errors.New("insufficient value data")
 		}
+		if valueLen > pvss.field.size {
+			return nil, 0, errors.New("share value exceeds the group order")
+		}
+
+		padded := make([]byte, pvss.field.size)
+		copy(padded[len(padded)-valueLen:], data[offset:offset+valueLen])
 
-		if valueLen == 0 {
-			values[i] = big.NewInt(0)
-		} else {
-			values[i] = new(big.Int).SetBytes(data[offset : offset+valueLen])
+		scalar, err := pvss.field.newScalar().SetBytes(padded)
+		if err != nil {
+			return nil, 0, errors.New("share value exceeds the group order")
 		}
+		scalars[i] = scalar
 		offset += valueLen
 	}
 
-	return id, values, nil
+	return scalars, offset, nil
 }
 
-func (pvss *PedersenVSS) serializeMetadata(threshold, chunkCount int, allCommitments [][]Point) []byte {
-	// Header: 1 byte threshold + 1 byte chunk count
-	result := []byte{byte(threshold), byte(chunkCount)}
+func (pvss *PedersenVSS) serializeMetadata(meta metadata) []byte {
+	result := appendHeader(nil, metadataMagic, pvss.group.ID(), meta.scheme, scalarFlags(meta.wholeScalar))
 
-	for chunkIdx := 0; chunkIdx < chunkCount; chunkIdx++ {
-		commitments := allCommitments[chunkIdx]
+	// Threshold, chunk count, share set ID
+	result = appendCount(result, meta.threshold)
+	result = appendCount(result, meta.chunkCount)
+	result = appendShareSetID(result, meta.set)
+
+	return append(result, pvss.serializeCommitments(meta)...)
+}
+
+func (pvss *PedersenVSS) serializeCommitments(meta metadata) []byte {
+	var result []byte
+
+	for chunkIdx := 0; chunkIdx < meta.chunkCount; chunkIdx++ {
+		commitments := meta.commitments[chunkIdx]
 		for _, commitment := range commitments {
-			result = append(result, pvss.serializeCommitment(commitment)...)
+			result = append(result, commitment.Bytes()...)
 		}
 	}
 
 	return result
 }
 
-func (pvss *PedersenVSS) deserializeMetadata(data []byte) (int, int, [][]Point, error) {
-	if len(data) < 2 {
-		return 0, 0, nil, errors.New("insufficient metadata")
+// deserializeMetadata parses metadata with or without a format header.
+// Metadata written before the header existed is [threshold][chunk count]
+// followed by the Feldman commitments, and is told apart by its length,
+// which no headered payload can have.
+func (pvss *PedersenVSS) deserializeMetadata(data []byte) (metadata, error) {
+	if pvss.isHeaderlessMetadata(data) {
+		threshold, chunkCount := int(data[0]), int(data[1])
+		allCommitments, err := pvss.readCommitments(data, 2, threshold, chunkCount)
+		if err != nil {
+			return metadata{}, err
+		}
+		return metadata{
+			scheme:      SchemeFeldman,
+			threshold:   threshold,
+			chunkCount:  chunkCount,
+			commitments: allCommitments,
+		}, nil
 	}
 
-	threshold := int(data[0])
-	chunkCount := int(data[1])
+	header, offset, err := readHeader(data, metadataMagic, pvss.group.ID())
+	if err != nil {
+		return metadata{}, err
+	}
+	scheme := header.scheme
+
+	threshold, offset, err := readCount(data, offset)
+	if err != nil {
+		return metadata{}, errors.New("insufficient metadata")
+	}
+	chunkCount, offset, err := readCount(data, offset)
+	if err != nil {
+		return metadata{}, errors.New("insufficient metadata")
+	}
 
 	if threshold < 1 || chunkCount < 1 {
-		return 0, 0, nil, errors.New("invalid threshold or chunk count")
+		return metadata{}, errors.New("invalid threshold or chunk count")
+	}
+
+	set, offset, err := readShareSetID(data, offset)
+	if err != nil {
+		return metadata{}, err
+	}
+
+	allCommitments, err := pvss.readCommitments(data, offset, threshold, chunkCount)
+	if err != nil {
+		return metadata{}, err
 	}
 
+	return metadata{
+		set:         set,
+		scheme:      scheme,
+		threshold:   threshold,
+		chunkCount:  chunkCount,
+		commitments: allCommitments,
+		wholeScalar: header.flags&flagWholeScalar != 0,
+	}, nil
+}
+
+// isHeaderlessMetadata reports whether data has the length of metadata
+// written before the header existed: two count bytes and one compressed
+// P-256 point per commitment. A headered payload is 17 bytes plus its
+// varint counts longer than its commitments, so never matches.
+func (pvss *PedersenVSS) isHeaderlessMetadata(data []byte) bool {
+	if pvss.checkHeaderless() != nil || len(data) < 2 || data[0] == 0 || data[1] == 0 {
+		return false
+	}
+	return len(data) == 2+int(data[0])*int(data[1])*pvss.group.ElementSize()
+}
+
+// readCommitments parses threshold commitments for each of chunkCount
+// chunks, which must run to the end of data.
+func (pvss *PedersenVSS) readCommitments(data []byte, offset, threshold, chunkCount int) ([][]Element, error) {
 	expectedCommitments := threshold * chunkCount
-	expectedSize := 2 + (expectedCommitments * 33)
+	elementSize := pvss.group.ElementSize()
+	expectedSize := offset + (expectedCommitments * elementSize)
 	if len(data) != expectedSize {
-		return 0, 0, nil, fmt.Errorf("metadata size mismatch: expected %d, got %d", expectedSize, len(data))
+		return nil, fmt.Errorf("metadata size mismatch: expected %d, got %d", expectedSize, len(data))
 	}
 
-	allCommitments := make([][]Point, chunkCount)
-	offset := 2
+	allCommitments := make([][]Element, chunkCount)
 
 	for chunkIdx := 0; chunkIdx < chunkCount; chunkIdx++ {
-		commitments := make([]Point, threshold)
+		commitments := make([]Element, threshold)
 		for i := 0; i < threshold; i++ {
-			commitment, err := pvss.deserializeCommitment(data[offset : offset+33])
+			commitment, err := pvss.group.DecodeElement(data[offset : offset+elementSize])
 			if err != nil {
-				return 0, 0, nil, fmt.Errorf("failed to deserialize commitment: %v", err)
+				return nil, fmt.Errorf("failed to deserialize commitment: %v", err)
 			}
 			commitments[i] = commitment
-			offset += 33
+			offset += elementSize
 		}
 		allCommitments[chunkIdx] = commitments
 	}
 
-	return threshold, chunkCount, allCommitments, nil
+	return allCommitments, nil
+}
+
+// decodeMetadataBytes verifies the checksum of a KeyCheck phrase and returns
+// the raw metadata it encodes.
+func (pvss *PedersenVSS) decodeMetadataBytes(keyCheck string) ([]byte, error) {
+	return pvss.decodePhrase(keyCheck, metadataMagic, "metadata")
 }
 
-func (pvss *PedersenVSS) SplitSecret(secret string, numShares, threshold int) ([]Share, error) {
+// decodePhrase verifies the checksum of a Key or KeyCheck phrase and decodes
+// the payload under it. Phrases printed before the header existed carry the
+// legacy checksum, so a phrase that fails the encoder's own version is
+// checked against ChecksumLegacy as well; it is accepted that way only if
+// its payload has no header, as every payload with one was written with the
+// SHA-256 checksum. kind names the phrase in errors.
+func (pvss *PedersenVSS) decodePhrase(phrase string, magic byte, kind string) ([]byte, error) {
+	encoder := pvss.encoderFor(phrase)
+	body, valid := encoder.VerifyChecksum(phrase)
+	legacy := false
+	if !valid && encoder.checksumVersion != ChecksumLegacy {
+		body, valid = encoder.VerifyChecksumVersion(phrase, ChecksumLegacy)
+		legacy = true
+	}
+	if !valid {
+		return nil, fmt.Errorf("invalid %s phrase checksum", kind)
+	}
+
+	data, err := pvss.decodePayload(encoder, body, magic)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s phrase: %v", kind, err)
+	}
+	if _, _, err := readHeader(data, magic, pvss.group.ID()); legacy && err == nil {
+		return nil, fmt.Errorf("invalid %s phrase checksum", kind)
+	}
+
+	return data, nil
+}
+
+// decodePayload decodes a Key or KeyCheck phrase, stripped of its checksum,
+// in the encoding mode it was written in. A headered payload is accepted in
+// the mode its flagPacked bit records, trying the encoder's own mode first;
+// a payload without a header was always written with EncodingBigInt. When
+// neither mode gives a payload whose header agrees, the encoder's own
+// decoding is returned for the parser to report.
+func (pvss *PedersenVSS) decodePayload(encoder *MnemonicEncoder, phrase string, magic byte) ([]byte, error) {
+	modes := []EncodingMode{encoder.encodingMode, EncodingPacked}
+	if encoder.encodingMode == EncodingPacked {
+		modes[1] = EncodingBigInt
+	}
+
+	decoded := make([][]byte, len(modes))
+	errs := make([]error, len(modes))
+	for i, mode := range modes {
+		decoded[i], errs[i] = encoder.withEncodingMode(mode).DecodeFromMnemonic(phrase)
+		if errs[i] == nil && headerRecordsMode(decoded[i], magic, pvss.group.ID(), mode) {
+			return decoded[i], nil
+		}
+	}
+
+	for i, mode := range modes {
+		if errs[i] == nil && mode == EncodingBigInt && (len(decoded[i]) == 0 || decoded[i][0] != magic) {
+			return decoded[i], nil
+		}
+	}
+
+	return decoded[0], errs[0]
+}
+
+// headerRecordsMode reports whether data starts with a readable header for
+// magic and curve that records the given encoding mode.
+func headerRecordsMode(data []byte, magic, curve byte, mode EncodingMode) bool {
+	header, _, err := readHeader(data, magic, curve)
+	return err == nil && (header.flags&flagPacked != 0) == (mode == EncodingPacked)
+}
+
+// decodeMetadata verifies and decodes a KeyCheck phrase.
+func (pvss *PedersenVSS) decodeMetadata(keyCheck string) (metadata, error) {
+	metadataBytes, err := pvss.decodeMetadataBytes(keyCheck)
+	if err != nil {
+		return metadata{}, err
+	}
+
+	meta, err := pvss.deserializeMetadata(metadataBytes)
+	if err != nil {
+		return metadata{}, fmt.Errorf("failed to parse metadata: %w", err)
+	}
+
+	return meta, nil
+}
+
+// SplitSecret splits secret into numShares shares, any threshold of which
+// reconstruct it. Options select the commitment scheme recorded in the
+// metadata; shares use Pedersen commitments by default.
+func (pvss *PedersenVSS) SplitSecret(secret string, numShares, threshold int, opts ...SplitOption) ([]Share, error) {
+	return pvss.SplitBytes([]byte(secret), numShares, threshold, opts...)
+}
+
+// SplitBytes splits a binary secret into numShares shares. The exact length
+// of every chunk is recorded so ReconstructBytes returns the secret
+// byte-for-byte, including leading zero bytes.
+func (pvss *PedersenVSS) SplitBytes(secret []byte, numShares, threshold int, opts ...SplitOption) ([]Share, error) {
+	options := defaultSplitOptions()
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if !options.scheme.valid() {
+		return nil, fmt.Errorf("unknown commitment scheme: %d", byte(options.scheme))
+	}
 	if threshold > numShares {
 		return nil, errors.New("threshold cannot be greater than number of shares")
 	}
@@ -314,21 +771,33 @@ func (pvss *PedersenVSS) SplitSecret(secret string, numShares, threshold int) ([
 	if numShares < 1 {
 		return nil, errors.New("number of shares must be at least 1")
 	}
-	if numShares > 255 {
-		return nil, errors.New("number of shares cannot exceed 255")
+	if numShares > maxShares {
+		return nil, fmt.Errorf("number of shares cannot exceed %d", maxShares)
 	}
-	if secret == "" {
+	if len(secret) == 0 {
 		return nil, errors.New("secret cannot be empty")
 	}
+	if limit := maxChunks * pvss.chunkSize(); len(secret) > limit {
+		return nil, fmt.Errorf("secret of %d bytes exceeds the maximum of %d bytes", len(secret), limit)
+	}
 
 	chunks := pvss.chunkSecret(secret)
 	chunkCount := len(chunks)
 
-	shareValues := make([][]*big.Int, numShares)
-	allCommitments := make([][]Point, chunkCount)
+	chunkSizes := make([]int, chunkCount)
+	for i, chunk := range chunks {
+		chunkSizes[i] = len(chunk)
+	}
+
+	shareValues := make([][]*Scalar, numShares)
+	shareBlindings := make([][]*Scalar, numShares)
+	allCommitments := make([][]Element, chunkCount)
 
 	for i := 0; i < numShares; i++ {
-		shareValues[i] = make([]*big.Int, chunkCount)
+		shareValues[i] = make([]*Scalar, chunkCount)
+		if options.scheme == SchemePedersen {
+			shareBlindings[i] = make([]*Scalar, chunkCount)
+		}
 	}
 
 	for chunkIdx, chunk := range chunks {
@@ -339,7 +808,20 @@ func (pvss *PedersenVSS) SplitSecret(secret string, numShares, threshold int) ([
 			return nil, fmt.Errorf("failed to generate polynomial for chunk %d: %v", chunkIdx, err)
 		}
 
-		commitments, err := pvss.generateCommitments(coefficients)
+		var blindings []*Scalar
+		if options.scheme == SchemePedersen {
+			blindingSecret, err := pvss.randomScalar()
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate blinding for chunk %d: %v", chunkIdx, err)
+			}
+
+			blindings, err = pvss.generateRandomPolynomial(blindingSecret, threshold)
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate blinding polynomial for chunk %d: %v", chunkIdx, err)
+			}
+		}
+
+		commitments, err := pvss.generateCommitments(coefficients, blindings)
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate commitments for chunk %d: %v", chunkIdx, err)
 		}
@@ -347,27 +829,44 @@ func (pvss *PedersenVSS) SplitSecret(secret string, numShares, threshold int) ([
 
 		for i := 0; i < numShares; i++ {
 			shareID := i + 1
-			shareValue := pvss.evaluatePolynomial(coefficients, shareID)
-			shareValues[i][chunkIdx] = shareValue
+			shareValues[i][chunkIdx] = pvss.evaluatePolynomial(coefficients, shareID)
+			if blindings != nil {
+				shareBlindings[i][chunkIdx] = pvss.evaluatePolynomial(blindings, shareID)
+			}
 		}
 	}
 
 	shares := make([]Share, numShares)
 
-	metadataBytes := pvss.serializeMetadata(threshold, chunkCount, allCommitments)
-	metedataMnemonics, err := pvss.mnemonicEncoder.EncodeToMnemonic(metadataBytes)
+	meta := metadata{
+		scheme:      options.scheme,
+		threshold:   threshold,
+		chunkCount:  chunkCount,
+		commitments: allCommitments,
+	}
+
+	set, err := newShareSetID(pvss.serializeCommitments(meta), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	meta.set = set
+
+	metadataPhrase, err := pvss.encodePhrase(pvss.serializeMetadata(meta))
 	if err != nil {
-		return nil, fmt.Errorf("failed to perform mnemonic conversion")
+		return nil, err
 	}
-	metadataPhrase := pvss.mnemonicEncoder.AddChecksum(metedataMnemonics)
 
 	for i := 0; i < numShares; i++ {
-		shareDataBytes := pvss.serializeShareData(i+1, shareValues[i])
-		sharedataMnemonics, err := pvss.mnemonicEncoder.EncodeToMnemonic(shareDataBytes)
+		sharePhrase, err := pvss.encodePhrase(pvss.serializeShareData(shareData{
+			id:        i + 1,
+			set:       set,
+			sizes:     chunkSizes,
+			values:    shareValues[i],
+			blindings: shareBlindings[i],
+		}))
 		if err != nil {
-			return nil, fmt.Errorf("failed to perform mnemonic conversion")
+			return nil, err
 		}
-		sharePhrase := pvss.mnemonicEncoder.AddChecksum(sharedataMnemonics)
 
 		shares[i] = Share{
 			Key:      sharePhrase,
@@ -378,71 +877,74 @@ func (pvss *PedersenVSS) SplitSecret(secret string, numShares, threshold int) ([
 	return shares, nil
 }
 
-func (pvss *PedersenVSS) VerifyShare(share Share) (bool, error) {
-	sharePhrase, shareValid := pvss.mnemonicEncoder.VerifyChecksum(share.Key)
-	if !shareValid {
-		return false, errors.New("invalid share phrase checksum")
+// encodePhrase writes a Key or KeyCheck payload as a checksummed phrase,
+// recording the encoding mode in its header.
+func (pvss *PedersenVSS) encodePhrase(payload []byte) (string, error) {
+	if pvss.mnemonicEncoder.encodingMode == EncodingPacked {
+		payload[4] |= flagPacked
 	}
 
-	shareDataBytes, err := pvss.mnemonicEncoder.DecodeFromMnemonic(sharePhrase)
+	mnemonic, err := pvss.mnemonicEncoder.EncodeToMnemonic(payload)
 	if err != nil {
-		return false, fmt.Errorf("failed to decode share phrase: %v", err)
+		return "", fmt.Errorf("failed to perform mnemonic conversion")
 	}
 
-	shareID, shareValues, err := pvss.deserializeShareData(shareDataBytes)
-	if err != nil {
-		return false, fmt.Errorf("failed to parse share data: %v", err)
-	}
-
-	metadataPhrase, metaValid := pvss.mnemonicEncoder.VerifyChecksum(share.KeyCheck)
-	if !metaValid {
-		return false, errors.New("invalid metadata phrase checksum")
-	}
+	return pvss.mnemonicEncoder.AddChecksum(mnemonic), nil
+}
 
-	metadataBytes, err := pvss.mnemonicEncoder.DecodeFromMnemonic(metadataPhrase)
+func (pvss *PedersenVSS) VerifyShare(share Share) (bool, error) {
+	data, err := pvss.decodeShareData(share.Key)
 	if err != nil {
-		return false, fmt.Errorf("failed to decode metadata phrase: %v", err)
+		return false, err
 	}
 
-	_, chunkCount, allCommitments, err := pvss.deserializeMetadata(metadataBytes)
+	meta, err := pvss.decodeMetadata(share.KeyCheck)
 	if err != nil {
-		return false, fmt.Errorf("failed to parse metadata: %v", err)
+		return false, err
 	}
 
+	return pvss.verifyShareData(data, meta)
+}
+
+// verifyShareData checks decoded share data against the commitments in meta.
+// Malformed shares return an error; well-formed shares that do not match the
+// commitments return false.
+func (pvss *PedersenVSS) verifyShareData(data shareData, meta metadata) (bool, error) {
 	// Validate consistency
-	if len(shareValues) != chunkCount {
-		return false, fmt.Errorf("share has %d chunks, metadata expects %d", len(shareValues), chunkCount)
+	if data.set != meta.set {
+		return false, fmt.Errorf("%w: share belongs to share set %s, metadata to %s", ErrMetadataMismatch, data.set, meta.set)
+	}
+	if data.wholeScalar != meta.wholeScalar {
+		return false, fmt.Errorf("%w: share and metadata disagree on whole-scalar chunks", ErrMetadataMismatch)
+	}
+	if len(data.values) != meta.chunkCount {
+		return false, fmt.Errorf("share has %d chunks, metadata expects %d", len(data.values), meta.chunkCount)
 	}
 
-	// Verify each chunk share using commitments
-	for chunkIdx, shareValue := range shareValues {
-		commitments := allCommitments[chunkIdx]
-
-		// Compute expected commitment using Horner's method
-		expectedX := big.NewInt(0)
-		expectedY := big.NewInt(0)
-		xBig := big.NewInt(int64(shareID))
-		xPower := big.NewInt(1)
-
-		for i, commitment := range commitments {
-			// Multiply commitment by x^i
-			tempX, tempY := pvss.curve.ScalarMult(commitment.X, commitment.Y, xPower.Bytes())
-
-			// Add to running sum
-			expectedX, expectedY = pvss.curve.Add(expectedX, expectedY, tempX, tempY)
-
-			// Update x power for next iteration
-			if i < len(commitments)-1 {
-				xPower.Mul(xPower, xBig)
-				xPower.Mod(xPower, pvss.order)
-			}
+	switch meta.scheme {
+	case SchemePedersen:
+		if len(data.blindings) != meta.chunkCount {
+			return false, errors.New("share is missing its blinding values")
 		}
+	case SchemeFeldman:
+		if data.blindings != nil {
+			return false, errors.New("share carries blinding values but metadata uses feldman commitments")
+		}
+	}
+
+	// Verify each chunk share using commitments
+	for chunkIdx, shareValue := range data.values {
+		expected := pvss.evaluateCommitments(meta.commitments[chunkIdx], data.id)
 
-		// Compute actual commitment g^shareValue
-		actualX, actualY := pvss.curve.ScalarBaseMult(shareValue.Bytes())
+		// Compute actual commitment g^shareValue·h^shareBlinding
+		var shareBlinding *Scalar
+		if meta.scheme == SchemePedersen {
+			shareBlinding = data.blindings[chunkIdx]
+		}
+		actual := pvss.commit(shareValue, shareBlinding)
 
 		// Verify commitments match
-		if expectedX.Cmp(actualX) != 0 || expectedY.Cmp(actualY) != 0 {
+		if !expected.Equal(actual) {
 			return false, nil // Invalid share (not an error, just invalid)
 		}
 	}
@@ -450,7 +952,9 @@ func (pvss *PedersenVSS) VerifyShare(share Share) (bool, error) {
 	return true, nil
 }
 
-func (pvss *PedersenVSS) lagrangeInterpolation(shareValues []*big.Int, shareIDs []int) (*big.Int, error) {
+// lagrangeInterpolation evaluates at zero the polynomial through the points
+// (shareIDs[i], shareValues[i]).
+func (pvss *PedersenVSS) lagrangeInterpolation(shareValues []*Scalar, shareIDs []int) (*Scalar, error) {
 	if len(shareValues) != len(shareIDs) {
 		return nil, errors.New("mismatched share values and IDs")
 	}
@@ -459,131 +963,180 @@ func (pvss *PedersenVSS) lagrangeInterpolation(shareValues []*big.Int, shareIDs
 		return nil, errors.New("no share values provided")
 	}
 
-	secret := big.NewInt(0)
+	coefficients, err := pvss.lagrangeCoefficients(shareIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return pvss.linearCombination(shareValues, coefficients), nil
+}
 
-	for i, shareValue := range shareValues {
-		numerator := big.NewInt(1)
-		denominator := big.NewInt(1)
+// linearCombination returns Σ values[i]·coefficients[i].
+func (pvss *PedersenVSS) linearCombination(values, coefficients []*Scalar) *Scalar {
+	result := pvss.field.newScalar()
+	term := pvss.field.newScalar()
+	for i, value := range values {
+		result.Add(result, term.Multiply(value, coefficients[i]))
+	}
+
+	return result
+}
 
-		for j, otherID := range shareIDs {
+// lagrangeCoefficients returns λ_i = Π_{j≠i} x_j / (x_j − x_i) for the
+// given share IDs, so that f(0) = Σ λ_i·f(x_i).
+func (pvss *PedersenVSS) lagrangeCoefficients(shareIDs []int) ([]*Scalar, error) {
+	return pvss.lagrangeCoefficientsAt(shareIDs, 0)
+}
+
+// lagrangeCoefficientsAt returns λ_i = Π_{j≠i} (x − x_j) / (x_i − x_j) for
+// the given share IDs, so that f(x) = Σ λ_i·f(x_i).
+func (pvss *PedersenVSS) lagrangeCoefficientsAt(shareIDs []int, x int) ([]*Scalar, error) {
+	xs := make([]*Scalar, len(shareIDs))
+	for i, id := range shareIDs {
+		xs[i] = pvss.field.newScalar().SetUint64(uint64(id))
+	}
+	at := pvss.field.newScalar().SetUint64(uint64(x))
+
+	coefficients := make([]*Scalar, len(shareIDs))
+	difference := pvss.field.newScalar()
+
+	for i := range xs {
+		numerator := pvss.field.newScalar().SetUint64(1)
+		denominator := pvss.field.newScalar().SetUint64(1)
+
+		for j := range xs {
 			if i != j {
-				temp := big.NewInt(int64(-otherID))
-				temp.Mod(temp, pvss.order)
-				numerator.Mul(numerator, temp)
-				numerator.Mod(numerator, pvss.order)
-
-				temp = big.NewInt(int64(shareIDs[i] - otherID))
-				temp.Mod(temp, pvss.order)
-				denominator.Mul(denominator, temp)
-				denominator.Mod(denominator, pvss.order)
+				numerator.Multiply(numerator, difference.Subtract(at, xs[j]))
+				denominator.Multiply(denominator, difference.Subtract(xs[i], xs[j]))
 			}
 		}
 
-		denomInverse := new(big.Int).ModInverse(denominator, pvss.order)
-		if denomInverse == nil {
+		if denominator.IsZero() {
 			return nil, fmt.Errorf("failed to compute modular inverse for share %d", shareIDs[i])
 		}
 
-		lagrangeCoeff := new(big.Int).Mul(numerator, denomInverse)
-		lagrangeCoeff.Mod(lagrangeCoeff, pvss.order)
+		coefficients[i] = numerator.Multiply(numerator, denominator.Invert(denominator))
+	}
 
-		term := new(big.Int).Mul(shareValue, lagrangeCoeff)
-		term.Mod(term, pvss.order)
+	return coefficients, nil
+}
 
-		secret.Add(secret, term)
-		secret.Mod(secret, pvss.order)
+func (pvss *PedersenVSS) ReconstructSecret(shares []Share) (string, error) {
+	secret, err := pvss.ReconstructBytes(shares)
+	if err != nil {
+		return "", err
 	}
 
-	return secret, nil
+	return string(secret), nil
 }
 
-func (pvss *PedersenVSS) ReconstructSecret(shares []Share) (string, error) {
+// ReconstructBytes reconstructs a secret split with SplitBytes or SplitSecret,
+// preserving its original length and content byte-for-byte.
+func (pvss *PedersenVSS) ReconstructBytes(shares []Share) ([]byte, error) {
 	if len(shares) == 0 {
-		return "", errors.New("no shares provided")
+		return nil, errors.New("no shares provided")
 	}
 
-	metadataPhrase, metaValid := pvss.mnemonicEncoder.VerifyChecksum(shares[0].KeyCheck)
-	if !metaValid {
-		return "", errors.New("invalid metadata phrase checksum")
+	shareDataList, meta, err := pvss.checkMetadataConsistency(shares)
+	if err != nil {
+		return nil, err
 	}
+	threshold, chunkCount := meta.threshold, meta.chunkCount
 
-	metadataBytes, err := pvss.mnemonicEncoder.DecodeFromMnemonic(metadataPhrase)
-	if err != nil {
-		return "", fmt.Errorf("failed to decode metadata phrase: %v", err)
+	if len(shares) < threshold {
+		return nil, fmt.Errorf("insufficient shares: need %d, got %d", threshold, len(shares))
 	}
 
-	threshold, chunkCount, _, err := pvss.deserializeMetadata(metadataBytes)
-	if err != nil {
-		return "", fmt.Errorf("failed to parse metadata: %v", err)
+	idMap := make(map[int]bool)
+	for i, data := range shareDataList {
+		if len(data.values) != chunkCount {
+			return nil, fmt.Errorf("share %d has %d chunks, expected %d", i, len(data.values), chunkCount)
+		}
+
+		if idMap[data.id] {
+			return nil, fmt.Errorf("duplicate share ID: %d", data.id)
+		}
+		idMap[data.id] = true
 	}
 
-	if len(shares) < threshold {
-		return "", fmt.Errorf("insufficient shares: need %d, got %d", threshold, len(shares))
+	return pvss.interpolateChunks(shareDataList, chunkCount)
+}
+
+// checkMetadataConsistency decodes every share, decoding each phrase once,
+// and confirms that every share carries the metadata most of the shares
+// carry and that every Key belongs to that split. It returns the decoded
+// Keys and the majority metadata.
+func (pvss *PedersenVSS) checkMetadataConsistency(shares []Share) ([]shareData, metadata, error) {
+	keyChecks := make([][]byte, len(shares))
+	keyCheckErrs := make([]error, len(shares))
+	for i, share := range shares {
+		keyChecks[i], keyCheckErrs[i] = pvss.decodeMetadataBytes(share.KeyCheck)
 	}
 
-	shareDataList := make([]struct {
-		id     int
-		values []*big.Int
-	}, len(shares))
+	meta, matches, err := pvss.majorityMetadata(keyChecks, keyCheckErrs)
+	if err != nil {
+		return nil, metadata{}, err
+	}
 
-	shareIDs := make([]int, len(shares))
+	decoded := make([]shareData, len(shares))
+	dataErrs := make([]error, len(shares))
 
+	var mismatch MetadataMismatchError
 	for i, share := range shares {
-		sharePhrase, shareValid := pvss.mnemonicEncoder.VerifyChecksum(share.Key)
-		if !shareValid {
-			return "", fmt.Errorf("invalid share phrase checksum for share %d", i)
-		}
+		decoded[i], dataErrs[i] = pvss.decodeShareData(share.Key)
 
-		shareDataBytes, err := pvss.mnemonicEncoder.DecodeFromMnemonic(sharePhrase)
-		if err != nil {
-			return "", fmt.Errorf("failed to decode share phrase %d: %v", i, err)
+		if matches[i] && (dataErrs[i] != nil || decoded[i].set == meta.set) {
+			continue
 		}
 
-		id, values, err := pvss.deserializeShareData(shareDataBytes)
+		mismatch.Indices = append(mismatch.Indices, i)
+		mismatch.ShareIDs = append(mismatch.ShareIDs, decoded[i].id)
+	}
+
+	if len(mismatch.Indices) > 0 {
+		return nil, metadata{}, &mismatch
+	}
+
+	for i, err := range dataErrs {
 		if err != nil {
-			return "", fmt.Errorf("failed to parse share data %d: %v", i, err)
+			return nil, metadata{}, fmt.Errorf("share %d: %v", i, err)
 		}
+	}
 
-		if len(values) != chunkCount {
-			return "", fmt.Errorf("share %d has %d chunks, expected %d", i, len(values), chunkCount)
-		}
+	return decoded, meta, nil
+}
 
-		shareDataList[i] = struct {
-			id     int
-			values []*big.Int
-		}{id: id, values: values}
-		shareIDs[i] = id
+// interpolateChunks recovers every chunk from the given shares and joins them
+// back into the secret.
+func (pvss *PedersenVSS) interpolateChunks(shareDataList []shareData, chunkCount int) ([]byte, error) {
+	shareIDs := make([]int, len(shareDataList))
+	for i, data := range shareDataList {
+		shareIDs[i] = data.id
 	}
 
-	idMap := make(map[int]bool)
-	for _, id := range shareIDs {
-		if idMap[id] {
-			return "", fmt.Errorf("duplicate share ID: %d", id)
-		}
-		idMap[id] = true
+	// The coefficients depend only on the share IDs, so every chunk shares
+	// them
+	coefficients, err := pvss.lagrangeCoefficients(shareIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct secret: %v", err)
 	}
 
-	reconstructedChunks := make([][]byte, chunkCount)
+	result := make([]byte, 0, chunkCount*pvss.chunkSize())
 
 	for chunkIdx := 0; chunkIdx < chunkCount; chunkIdx++ {
-		chunkShares := make([]*big.Int, len(shares))
-		for i, shareData := range shareDataList {
-			chunkShares[i] = shareData.values[chunkIdx]
+		chunkShares := make([]*Scalar, len(shareDataList))
+		for i, data := range shareDataList {
+			chunkShares[i] = data.values[chunkIdx]
 		}
 
-		reconstructedSecret, err := pvss.lagrangeInterpolation(chunkShares, shareIDs)
+		reconstructedSecret := pvss.linearCombination(chunkShares, coefficients)
+
+		chunk, err := pvss.secretToChunk(reconstructedSecret, shareDataList[0].sizes[chunkIdx])
 		if err != nil {
-			return "", fmt.Errorf("failed to reconstruct chunk %d: %v", chunkIdx, err)
+			return nil, fmt.Errorf("failed to reconstruct chunk %d: %v", chunkIdx, err)
 		}
-
-		chunk := pvss.secretToChunk(reconstructedSecret)
-		reconstructedChunks[chunkIdx] = chunk
-	}
-
-	result := make([]byte, 0)
-	for _, chunk := range reconstructedChunks {
 		result = append(result, chunk...)
 	}
 
-	return string(result), nil
+	return result, nil
 }
@@ -1,7 +1,12 @@
 package pvss
 
 import (
+	"bytes"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
 	"math/big"
+	"reflect"
 	"strings"
 	"testing"
 )
@@ -14,12 +19,12 @@ func TestNewPedersenVSS(t *testing.T) {
 		t.Fatal("NewPedersenVSS returned nil")
 	}
 
-	if pvss.curve == nil {
-		t.Error("curve is nil")
+	if pvss.group == nil {
+		t.Error("group is nil")
 	}
 
-	if pvss.order == nil {
-		t.Error("order is nil")
+	if pvss.field == nil {
+		t.Error("scalar field is nil")
 	}
 
 	if pvss.mnemonicEncoder == nil {
@@ -27,6 +32,25 @@ func TestNewPedersenVSS(t *testing.T) {
 	}
 }
 
+// TestPedersenGenerator tests the derivation of the second generator H
+func TestPedersenGenerator(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	if _, err := pvss.group.DecodeElement(pvss.h.Bytes()); err != nil {
+		t.Fatalf("H is not on curve: %v", err)
+	}
+
+	if pvss.h.Equal(pvss.group.Generator()) {
+		t.Error("H must differ from the base point")
+	}
+
+	// H is derived deterministically so every instance agrees on it
+	other := NewPedersenVSS()
+	if !other.h.Equal(pvss.h) {
+		t.Error("H is not deterministic")
+	}
+}
+
 // TestChunkSecret tests secret chunking
 func TestChunkSecret(t *testing.T) {
 	pvss := NewPedersenVSS()
@@ -81,7 +105,7 @@ func TestChunkSecret(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			chunks := pvss.chunkSecret(tt.secret)
+			chunks := pvss.chunkSecret([]byte(tt.secret))
 
 			if len(chunks) != tt.expectedChunks {
 				t.Errorf("expected %d chunks, got %d", tt.expectedChunks, len(chunks))
@@ -137,6 +161,10 @@ func TestChunkToSecret(t *testing.T) {
 			name:  "max safe chunk (31 bytes)",
 			chunk: make([]byte, 31),
 		},
+		{
+			name:  "leading zeros",
+			chunk: []byte{0, 0, 7, 8},
+		},
 	}
 
 	for _, tt := range tests {
@@ -148,44 +176,29 @@ func TestChunkToSecret(t *testing.T) {
 			}
 
 			// Verify it's within order
-			if secretInt.Cmp(pvss.order) >= 0 {
+			if new(big.Int).SetBytes(secretInt.Bytes()).Cmp(pvss.group.Order()) >= 0 {
 				t.Error("secret exceeds curve order")
 			}
 
-			// Verify round trip for non-empty chunks
-			if len(tt.chunk) > 0 {
-				reconstructed := pvss.secretToChunk(secretInt)
-				if len(reconstructed) > 0 && tt.chunk[0] != 0 {
-					// Compare without leading zeros
-					if !bytesEqualIgnoreLeadingZeros(tt.chunk, reconstructed) {
-						t.Errorf("round trip failed: original=%v, reconstructed=%v", tt.chunk, reconstructed)
-					}
-				}
+			// Verify round trip, including leading zero bytes
+			reconstructed, err := pvss.secretToChunk(secretInt, len(tt.chunk))
+			if err != nil {
+				t.Fatalf("secretToChunk failed: %v", err)
+			}
+			if !bytes.Equal(tt.chunk, reconstructed) {
+				t.Errorf("round trip failed: original=%v, reconstructed=%v", tt.chunk, reconstructed)
 			}
 		})
 	}
 }
 
-// Helper function to compare bytes ignoring leading zeros
-func bytesEqualIgnoreLeadingZeros(a, b []byte) bool {
-	// Trim leading zeros
-	for len(a) > 0 && a[0] == 0 {
-		a = a[1:]
-	}
-	for len(b) > 0 && b[0] == 0 {
-		b = b[1:]
-	}
-
-	if len(a) != len(b) {
-		return false
-	}
+// TestSecretToChunk_Overflow tests that values too large for the recorded length are rejected
+func TestSecretToChunk_Overflow(t *testing.T) {
+	pvss := NewPedersenVSS()
 
-	for i := range a {
-		if a[i] != b[i] {
-			return false
-		}
+	if _, err := pvss.secretToChunk(testScalar(256), 1); err == nil {
+		t.Error("expected error for value exceeding chunk length")
 	}
-	return true
 }
 
 // TestGenerateRandomPolynomial tests polynomial generation
@@ -194,31 +207,31 @@ func TestGenerateRandomPolynomial(t *testing.T) {
 
 	tests := []struct {
 		name        string
-		secret      *big.Int
+		secret      *Scalar
 		threshold   int
 		expectError bool
 	}{
 		{
 			name:        "threshold 1",
-			secret:      big.NewInt(42),
+			secret:      testScalar(42),
 			threshold:   1,
 			expectError: false,
 		},
 		{
 			name:        "threshold 3",
-			secret:      big.NewInt(100),
+			secret:      testScalar(100),
 			threshold:   3,
 			expectError: false,
 		},
 		{
 			name:        "threshold 0",
-			secret:      big.NewInt(50),
+			secret:      testScalar(50),
 			threshold:   0,
 			expectError: true,
 		},
 		{
 			name:        "negative threshold",
-			secret:      big.NewInt(50),
+			secret:      testScalar(50),
 			threshold:   -1,
 			expectError: true,
 		},
@@ -244,13 +257,13 @@ func TestGenerateRandomPolynomial(t *testing.T) {
 			}
 
 			// Verify first coefficient is the secret
-			if coeffs[0].Cmp(tt.secret) != 0 {
+			if !coeffs[0].Equal(tt.secret) {
 				t.Error("first coefficient is not the secret")
 			}
 
 			// Verify other coefficients are within order
 			for i := 1; i < len(coeffs); i++ {
-				if coeffs[i].Cmp(pvss.order) >= 0 {
+				if new(big.Int).SetBytes(coeffs[i].Bytes()).Cmp(pvss.group.Order()) >= 0 {
 					t.Errorf("coefficient %d exceeds order", i)
 				}
 			}
@@ -264,33 +277,33 @@ func TestEvaluatePolynomial(t *testing.T) {
 
 	tests := []struct {
 		name         string
-		coefficients []*big.Int
+		coefficients []*Scalar
 		x            int
-		expected     *big.Int
+		expected     *Scalar
 	}{
 		{
 			name:         "empty polynomial",
-			coefficients: []*big.Int{},
+			coefficients: []*Scalar{},
 			x:            1,
-			expected:     big.NewInt(0),
+			expected:     testScalar(0),
 		},
 		{
 			name:         "constant polynomial",
-			coefficients: []*big.Int{big.NewInt(5)},
+			coefficients: []*Scalar{testScalar(5)},
 			x:            10,
-			expected:     big.NewInt(5),
+			expected:     testScalar(5),
 		},
 		{
 			name:         "linear polynomial: 3 + 2x at x=1",
-			coefficients: []*big.Int{big.NewInt(3), big.NewInt(2)},
+			coefficients: []*Scalar{testScalar(3), testScalar(2)},
 			x:            1,
-			expected:     big.NewInt(5),
+			expected:     testScalar(5),
 		},
 		{
 			name:         "linear polynomial: 3 + 2x at x=2",
-			coefficients: []*big.Int{big.NewInt(3), big.NewInt(2)},
+			coefficients: []*Scalar{testScalar(3), testScalar(2)},
 			x:            2,
-			expected:     big.NewInt(7),
+			expected:     testScalar(7),
 		},
 	}
 
@@ -298,11 +311,7 @@ func TestEvaluatePolynomial(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			result := pvss.evaluatePolynomial(tt.coefficients, tt.x)
 
-			// Result should be modulo order
-			result.Mod(result, pvss.order)
-			tt.expected.Mod(tt.expected, pvss.order)
-
-			if result.Cmp(tt.expected) != 0 {
+			if !result.Equal(tt.expected) {
 				t.Errorf("expected %v, got %v", tt.expected, result)
 			}
 		})
@@ -313,13 +322,19 @@ func TestEvaluatePolynomial(t *testing.T) {
 func TestGenerateCommitments(t *testing.T) {
 	pvss := NewPedersenVSS()
 
-	coeffs := []*big.Int{
-		big.NewInt(42),
-		big.NewInt(100),
-		big.NewInt(200),
+	coeffs := []*Scalar{
+		testScalar(42),
+		testScalar(100),
+		testScalar(200),
+	}
+
+	blindings := []*Scalar{
+		testScalar(7),
+		testScalar(8),
+		testScalar(9),
 	}
 
-	commitments, err := pvss.generateCommitments(coeffs)
+	commitments, err := pvss.generateCommitments(coeffs, blindings)
 
 	if err != nil {
 		t.Fatalf("generateCommitments failed: %v", err)
@@ -331,13 +346,14 @@ func TestGenerateCommitments(t *testing.T) {
 
 	// Verify all commitments are valid points
 	for i, commitment := range commitments {
-		if commitment.X == nil || commitment.Y == nil {
-			t.Errorf("commitment %d has nil coordinates", i)
+		if commitment == nil {
+			t.Errorf("commitment %d is nil", i)
+			continue
 		}
 
 		// Verify point is on curve
-		if !pvss.curve.IsOnCurve(commitment.X, commitment.Y) {
-			t.Errorf("commitment %d is not on curve", i)
+		if _, err := pvss.group.DecodeElement(commitment.Bytes()); err != nil {
+			t.Errorf("commitment %d is not on curve: %v", i, err)
 		}
 	}
 }
@@ -347,12 +363,11 @@ func TestSerializeDeserializeCommitment(t *testing.T) {
 	pvss := NewPedersenVSS()
 
 	// Generate a test point
-	scalar := big.NewInt(42)
-	x, y := pvss.curve.ScalarBaseMult(scalar.Bytes())
-	point := Point{X: x, Y: y}
+	scalar := testScalar(42)
+	point := pvss.group.Generator().ScalarMult(scalar)
 
 	// Serialize
-	serialized := pvss.serializeCommitment(point)
+	serialized := point.Bytes()
 
 	if len(serialized) != 33 {
 		t.Errorf("expected 33 bytes, got %d", len(serialized))
@@ -364,13 +379,13 @@ func TestSerializeDeserializeCommitment(t *testing.T) {
 	}
 
 	// Deserialize
-	reconstructed, err := pvss.deserializeCommitment(serialized)
+	reconstructed, err := pvss.group.DecodeElement(serialized)
 	if err != nil {
 		t.Fatalf("deserialization failed: %v", err)
 	}
 
 	// Verify reconstruction
-	if reconstructed.X.Cmp(point.X) != 0 || reconstructed.Y.Cmp(point.Y) != 0 {
+	if !reconstructed.Equal(point) {
 		t.Error("deserialized point doesn't match original")
 	}
 }
@@ -399,7 +414,7 @@ func TestSerializeDeserializeCommitment_Invalid(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, err := pvss.deserializeCommitment(tt.data)
+			_, err := pvss.group.DecodeElement(tt.data)
 			if err == nil {
 				t.Error("expected error but got nil")
 			}
@@ -414,38 +429,60 @@ func TestSerializeDeserializeShareData(t *testing.T) {
 	tests := []struct {
 		name   string
 		id     int
-		values []*big.Int
+		values []*Scalar
 	}{
 		{
 			name:   "single value",
 			id:     1,
-			values: []*big.Int{big.NewInt(42)},
+			values: []*Scalar{testScalar(42)},
 		},
 		{
 			name:   "multiple values",
 			id:     5,
-			values: []*big.Int{big.NewInt(100), big.NewInt(200), big.NewInt(300)},
+			values: []*Scalar{testScalar(100), testScalar(200), testScalar(300)},
 		},
 		{
 			name:   "empty values",
 			id:     3,
-			values: []*big.Int{},
+			values: []*Scalar{},
 		},
 		{
 			name:   "large values",
 			id:     10,
-			values: []*big.Int{new(big.Int).Exp(big.NewInt(2), big.NewInt(128), nil)},
+			values: []*Scalar{bigScalar(t, pvss.group, new(big.Int).Exp(big.NewInt(2), big.NewInt(128), nil))},
+		},
+		{
+			name:   "largest value",
+			id:     11,
+			values: []*Scalar{NewScalar(pvss.group).Negate(testScalar(1))},
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			serialized := pvss.serializeShareData(tt.id, tt.values)
+			blindings := make([]*Scalar, len(tt.values))
+			for i := range blindings {
+				blindings[i] = testScalar(uint64(1000 + i))
+			}
+
+			sizes := make([]int, len(tt.values))
+			for i := range sizes {
+				sizes[i] = pvss.chunkSize() - i
+			}
 
-			id, values, err := pvss.deserializeShareData(serialized)
+			serialized := pvss.serializeShareData(shareData{id: tt.id, sizes: sizes, values: tt.values, blindings: blindings})
+
+			data, err := pvss.deserializeShareData(serialized)
 			if err != nil {
 				t.Fatalf("deserialization failed: %v", err)
 			}
+			id, values, recBlindings := data.id, data.values, data.blindings
+
+			for i := range data.sizes {
+				if data.sizes[i] != sizes[i] {
+					t.Errorf("size %d mismatch: expected %d, got %d", i, sizes[i], data.sizes[i])
+				}
+			}
 
 			if id != tt.id {
 				t.Errorf("expected id %d, got %d", tt.id, id)
@@ -456,14 +493,46 @@ func TestSerializeDeserializeShareData(t *testing.T) {
 			}
 
 			for i := range values {
-				if values[i].Cmp(tt.values[i]) != 0 {
+				if !values[i].Equal(tt.values[i]) {
 					t.Errorf("value %d mismatch: expected %v, got %v", i, tt.values[i], values[i])
 				}
 			}
+
+			if len(recBlindings) != len(blindings) {
+				t.Errorf("expected %d blinding values, got %d", len(blindings), len(recBlindings))
+			}
+
+			for i := range recBlindings {
+				if !recBlindings[i].Equal(blindings[i]) {
+					t.Errorf("blinding %d mismatch: expected %v, got %v", i, blindings[i], recBlindings[i])
+				}
+			}
 		})
 	}
 }
 
+// TestDeserializeShareData_WithoutBlindings tests share data that carries no blinding values
+func TestDeserializeShareData_WithoutBlindings(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	values := []*Scalar{testScalar(42), testScalar(43)}
+	serialized := pvss.serializeShareData(shareData{id: 2, sizes: []int{1, 1}, values: values})
+
+	data, err := pvss.deserializeShareData(serialized)
+	if err != nil {
+		t.Fatalf("deserialization failed: %v", err)
+	}
+	id, recValues, blindings := data.id, data.values, data.blindings
+
+	if id != 2 || len(recValues) != len(values) {
+		t.Errorf("unexpected share data: id=%d values=%v", id, recValues)
+	}
+
+	if blindings != nil {
+		t.Errorf("expected no blinding values, got %v", blindings)
+	}
+}
+
 // TestSplitSecret_BasicCases tests basic secret splitting
 func TestSplitSecret_BasicCases(t *testing.T) {
 	pvss := NewPedersenVSS()
@@ -561,9 +630,9 @@ func TestSplitSecret_ValidationErrors(t *testing.T) {
 			threshold: 1,
 		},
 		{
-			name:      "numShares > 255",
+			name:      "numShares > maxShares",
 			secret:    "test",
-			numShares: 256,
+			numShares: maxShares + 1,
 			threshold: 128,
 		},
 		{
@@ -626,6 +695,136 @@ func TestVerifyShare(t *testing.T) {
 	})
 }
 
+// TestVerifyShare_TamperedValues tests that altered share or blinding values fail verification
+func TestVerifyShare_TamperedValues(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	shares, err := pvss.SplitSecret("pedersen secret", 5, 3)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+
+	decode := func() shareData {
+		data, err := pvss.decodeShareData(shares[0].Key)
+		if err != nil {
+			t.Fatalf("decodeShareData failed: %v", err)
+		}
+		return data
+	}
+
+	encode := func(data shareData) Share {
+		mnemonic, err := pvss.mnemonicEncoder.EncodeToMnemonic(pvss.serializeShareData(data))
+		if err != nil {
+			t.Fatalf("encode failed: %v", err)
+		}
+		return Share{Key: pvss.mnemonicEncoder.AddChecksum(mnemonic), KeyCheck: shares[0].KeyCheck}
+	}
+
+	t.Run("tampered value", func(t *testing.T) {
+		data := decode()
+		data.values[0].Add(data.values[0], testScalar(1))
+
+		valid, err := pvss.VerifyShare(encode(data))
+		if err != nil {
+			t.Fatalf("VerifyShare failed: %v", err)
+		}
+		if valid {
+			t.Error("share with tampered value marked as valid")
+		}
+	})
+
+	t.Run("tampered blinding", func(t *testing.T) {
+		data := decode()
+		data.blindings[0].Add(data.blindings[0], testScalar(1))
+
+		valid, err := pvss.VerifyShare(encode(data))
+		if err != nil {
+			t.Fatalf("VerifyShare failed: %v", err)
+		}
+		if valid {
+			t.Error("share with tampered blinding marked as valid")
+		}
+	})
+
+	t.Run("missing blinding", func(t *testing.T) {
+		data := decode()
+		data.blindings = nil
+
+		if _, err := pvss.VerifyShare(encode(data)); err == nil {
+			t.Error("expected error for share without blinding values")
+		}
+	})
+}
+
+// TestCommitmentSchemes tests splitting, verifying and reconstructing with each scheme
+func TestCommitmentSchemes(t *testing.T) {
+	pvss := NewPedersenVSS()
+	secret := "scheme selection secret"
+
+	schemes := []CommitmentScheme{SchemeFeldman, SchemePedersen}
+
+	for _, scheme := range schemes {
+		t.Run(scheme.String(), func(t *testing.T) {
+			shares, err := pvss.SplitSecret(secret, 5, 3, WithCommitmentScheme(scheme))
+			if err != nil {
+				t.Fatalf("SplitSecret failed: %v", err)
+			}
+
+			meta, err := pvss.decodeMetadata(shares[0].KeyCheck)
+			if err != nil {
+				t.Fatalf("decodeMetadata failed: %v", err)
+			}
+			if meta.scheme != scheme {
+				t.Errorf("metadata records scheme %v, expected %v", meta.scheme, scheme)
+			}
+
+			for i, share := range shares {
+				valid, err := pvss.VerifyShare(share)
+				if err != nil {
+					t.Fatalf("VerifyShare failed for share %d: %v", i, err)
+				}
+				if !valid {
+					t.Errorf("share %d marked as invalid", i)
+				}
+			}
+
+			reconstructed, err := pvss.ReconstructSecret(shares[1:4])
+			if err != nil {
+				t.Fatalf("ReconstructSecret failed: %v", err)
+			}
+			if reconstructed != secret {
+				t.Errorf("expected %q, got %q", secret, reconstructed)
+			}
+		})
+	}
+
+	t.Run("feldman shares are smaller", func(t *testing.T) {
+		feldman, _ := pvss.SplitSecret(secret, 3, 2, WithCommitmentScheme(SchemeFeldman))
+		pedersen, _ := pvss.SplitSecret(secret, 3, 2, WithCommitmentScheme(SchemePedersen))
+
+		if len(strings.Fields(feldman[0].Key)) >= len(strings.Fields(pedersen[0].Key)) {
+			t.Error("expected feldman share phrase to be shorter than pedersen")
+		}
+	})
+
+	t.Run("mixed share sets", func(t *testing.T) {
+		feldman, _ := pvss.SplitSecret(secret, 3, 2, WithCommitmentScheme(SchemeFeldman))
+		pedersen, _ := pvss.SplitSecret(secret, 3, 2, WithCommitmentScheme(SchemePedersen))
+
+		// A Pedersen share checked against Feldman metadata must not pass
+		mismatched := Share{Key: pedersen[0].Key, KeyCheck: feldman[0].KeyCheck}
+		if valid, err := pvss.VerifyShare(mismatched); err == nil && valid {
+			t.Error("pedersen share verified against feldman metadata")
+		}
+	})
+
+	t.Run("unknown scheme", func(t *testing.T) {
+		if _, err := pvss.SplitSecret(secret, 3, 2, WithCommitmentScheme(CommitmentScheme(7))); err == nil {
+			t.Error("expected error for unknown commitment scheme")
+		}
+	})
+}
+
 // TestReconstructSecret tests secret reconstruction
 func TestReconstructSecret(t *testing.T) {
 	pvss := NewPedersenVSS()
@@ -791,6 +990,93 @@ func TestLongSecrets(t *testing.T) {
 	}
 }
 
+// TestSplitBytes tests binary secrets survive a split and reconstruction byte-for-byte
+func TestSplitBytes(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	seed := make([]byte, 32)
+	rand.Read(seed)
+	seed[0] = 0
+
+	tests := []struct {
+		name   string
+		secret []byte
+	}{
+		{
+			name:   "ed25519 seed with leading zero",
+			secret: seed,
+		},
+		{
+			name:   "single zero byte",
+			secret: []byte{0},
+		},
+		{
+			name:   "all zeros",
+			secret: make([]byte, 40),
+		},
+		{
+			name:   "zero at chunk boundary",
+			secret: append(bytes.Repeat([]byte{0xff}, 31), 0, 0, 1),
+		},
+		{
+			name:   "trailing zeros",
+			secret: []byte{1, 2, 3, 0, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			shares, err := pvss.SplitBytes(tt.secret, 5, 3)
+			if err != nil {
+				t.Fatalf("SplitBytes failed: %v", err)
+			}
+
+			for i, share := range shares {
+				valid, err := pvss.VerifyShare(share)
+				if err != nil || !valid {
+					t.Fatalf("share %d failed verification: valid=%v err=%v", i, valid, err)
+				}
+			}
+
+			reconstructed, err := pvss.ReconstructBytes(shares[2:])
+			if err != nil {
+				t.Fatalf("ReconstructBytes failed: %v", err)
+			}
+
+			if !bytes.Equal(reconstructed, tt.secret) {
+				t.Errorf("reconstruction mismatch:\nexpected: %x\ngot: %x", tt.secret, reconstructed)
+			}
+		})
+	}
+
+	t.Run("zero secret with feldman commitments", func(t *testing.T) {
+		secret := make([]byte, 4)
+		shares, err := pvss.SplitBytes(secret, 3, 2, WithCommitmentScheme(SchemeFeldman))
+		if err != nil {
+			t.Fatalf("SplitBytes failed: %v", err)
+		}
+
+		valid, err := pvss.VerifyShare(shares[0])
+		if err != nil || !valid {
+			t.Fatalf("share failed verification: valid=%v err=%v", valid, err)
+		}
+
+		reconstructed, err := pvss.ReconstructBytes(shares[:2])
+		if err != nil {
+			t.Fatalf("ReconstructBytes failed: %v", err)
+		}
+		if !bytes.Equal(reconstructed, secret) {
+			t.Errorf("expected %x, got %x", secret, reconstructed)
+		}
+	})
+
+	t.Run("empty secret", func(t *testing.T) {
+		if _, err := pvss.SplitBytes(nil, 3, 2); err == nil {
+			t.Error("expected error for empty secret")
+		}
+	})
+}
+
 // TestSpecialCharacters tests with special characters
 func TestSpecialCharacters(t *testing.T) {
 	pvss := NewPedersenVSS()
@@ -828,7 +1114,7 @@ func TestLagrangeInterpolation(t *testing.T) {
 	pvss := NewPedersenVSS()
 
 	// Simple test: secret = 42, threshold = 2 (linear)
-	secret := big.NewInt(42)
+	secret := testScalar(42)
 	coeffs, _ := pvss.generateRandomPolynomial(secret, 2)
 
 	// Evaluate at x=1, x=2, x=3
@@ -837,7 +1123,7 @@ func TestLagrangeInterpolation(t *testing.T) {
 	y3 := pvss.evaluatePolynomial(coeffs, 3)
 
 	// Reconstruct using first two points
-	shareValues := []*big.Int{y1, y2}
+	shareValues := []*Scalar{y1, y2}
 	shareIDs := []int{1, 2}
 
 	reconstructed, err := pvss.lagrangeInterpolation(shareValues, shareIDs)
@@ -845,12 +1131,12 @@ func TestLagrangeInterpolation(t *testing.T) {
 		t.Fatalf("lagrangeInterpolation failed: %v", err)
 	}
 
-	if reconstructed.Cmp(secret) != 0 {
+	if !reconstructed.Equal(secret) {
 		t.Errorf("expected %v, got %v", secret, reconstructed)
 	}
 
 	// Try with different points
-	shareValues = []*big.Int{y1, y3}
+	shareValues = []*Scalar{y1, y3}
 	shareIDs = []int{1, 3}
 
 	reconstructed, err = pvss.lagrangeInterpolation(shareValues, shareIDs)
@@ -858,7 +1144,7 @@ func TestLagrangeInterpolation(t *testing.T) {
 		t.Fatalf("lagrangeInterpolation failed: %v", err)
 	}
 
-	if reconstructed.Cmp(secret) != 0 {
+	if !reconstructed.Equal(secret) {
 		t.Errorf("expected %v, got %v", secret, reconstructed)
 	}
 }
@@ -1126,6 +1412,83 @@ func TestReconstructWithWrongMetadata(t *testing.T) {
 	}
 }
 
+// TestReconstructSecret_MetadataMismatch tests that shares from different splits are named in a typed error
+func TestReconstructSecret_MetadataMismatch(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	// Same length secrets produce the same chunk count
+	oldShares, _ := pvss.SplitSecret("rotated-secret-1", 5, 3)
+	newShares, _ := pvss.SplitSecret("rotated-secret-2", 5, 3)
+
+	mixed := []Share{newShares[0], oldShares[1], newShares[2], oldShares[3]}
+
+	_, err := pvss.ReconstructSecret(mixed)
+	if err == nil {
+		t.Fatal("expected error for shares from different splits")
+	}
+
+	var mismatch *MetadataMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("expected MetadataMismatchError, got %T: %v", err, err)
+	}
+
+	if !reflect.DeepEqual(mismatch.Indices, []int{1, 3}) {
+		t.Errorf("expected mismatched indices [1 3], got %v", mismatch.Indices)
+	}
+
+	if !reflect.DeepEqual(mismatch.ShareIDs, []int{2, 4}) {
+		t.Errorf("expected mismatched share IDs [2 4], got %v", mismatch.ShareIDs)
+	}
+
+	if !errors.Is(err, ErrMetadataMismatch) {
+		t.Error("expected error to match ErrMetadataMismatch")
+	}
+
+	// Whitespace differences in a KeyCheck phrase are not a mismatch
+	respaced := newShares[1]
+	respaced.KeyCheck = "  " + strings.ReplaceAll(respaced.KeyCheck, " ", "\n") + " "
+	if _, err := pvss.ReconstructSecret([]Share{newShares[0], respaced, newShares[2]}); err != nil {
+		t.Errorf("unexpected error for reformatted KeyCheck: %v", err)
+	}
+}
+
+// TestReconstructSecret_MajorityMetadata tests that a stale share passed
+// first is the one named, and that a KeyCheck re-encoded in the other
+// mnemonic mode still belongs to its split
+func TestReconstructSecret_MajorityMetadata(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	oldShares, _ := pvss.SplitSecret("rotated-secret-1", 5, 3)
+	newShares, _ := pvss.SplitSecret("rotated-secret-2", 5, 3)
+
+	_, err := pvss.ReconstructSecret([]Share{oldShares[0], newShares[1], newShares[2], newShares[3]})
+	var mismatch *MetadataMismatchError
+	if !errors.As(err, &mismatch) {
+		t.Fatalf("expected MetadataMismatchError, got %T: %v", err, err)
+	}
+	if !reflect.DeepEqual(mismatch.Indices, []int{0}) || !reflect.DeepEqual(mismatch.ShareIDs, []int{1}) {
+		t.Errorf("expected only share 0 (ID 1) named, got %v", err)
+	}
+
+	packed := NewPedersenVSS(WithMnemonicEncoder(NewMnemonicEncoder(BIP39EnglishWords(), WithEncodingMode(EncodingPacked))))
+	payload, err := pvss.decodeMetadataBytes(newShares[0].KeyCheck)
+	if err != nil {
+		t.Fatalf("decodeMetadataBytes failed: %v", err)
+	}
+	reencoded := newShares[0]
+	if reencoded.KeyCheck, err = packed.encodePhrase(payload); err != nil {
+		t.Fatalf("encodePhrase failed: %v", err)
+	}
+
+	reconstructed, err := pvss.ReconstructSecret([]Share{reencoded, newShares[1], newShares[2]})
+	if err != nil {
+		t.Fatalf("unexpected error for re-encoded KeyCheck: %v", err)
+	}
+	if reconstructed != "rotated-secret-2" {
+		t.Errorf("expected %q, got %q", "rotated-secret-2", reconstructed)
+	}
+}
+
 // TestSerializeMetadata tests metadata serialization
 func TestSerializeMetadata(t *testing.T) {
 	pvss := NewPedersenVSS()
@@ -1133,37 +1496,45 @@ func TestSerializeMetadata(t *testing.T) {
 	// Create sample commitments
 	threshold := 3
 	chunkCount := 2
-	allCommitments := make([][]Point, chunkCount)
+	allCommitments := make([][]Element, chunkCount)
 
 	for i := 0; i < chunkCount; i++ {
-		commitments := make([]Point, threshold)
+		commitments := make([]Element, threshold)
 		for j := 0; j < threshold; j++ {
-			scalar := big.NewInt(int64(i*10 + j))
-			x, y := pvss.curve.ScalarBaseMult(scalar.Bytes())
-			commitments[j] = Point{X: x, Y: y}
+			scalar := testScalar(uint64(i*10 + j))
+			commitments[j] = pvss.group.Generator().ScalarMult(scalar)
 		}
 		allCommitments[i] = commitments
 	}
 
 	// Serialize
-	serialized := pvss.serializeMetadata(threshold, chunkCount, allCommitments)
+	serialized := pvss.serializeMetadata(metadata{
+		scheme:      SchemeFeldman,
+		threshold:   threshold,
+		chunkCount:  chunkCount,
+		commitments: allCommitments,
+	})
 
 	// Deserialize
-	recThreshold, recChunkCount, recCommitments, err := pvss.deserializeMetadata(serialized)
+	meta, err := pvss.deserializeMetadata(serialized)
 	if err != nil {
 		t.Fatalf("deserializeMetadata failed: %v", err)
 	}
 
 	// Verify
-	if recThreshold != threshold {
-		t.Errorf("threshold mismatch: expected %d, got %d", threshold, recThreshold)
+	if meta.scheme != SchemeFeldman {
+		t.Errorf("scheme mismatch: expected %v, got %v", SchemeFeldman, meta.scheme)
+	}
+
+	if meta.threshold != threshold {
+		t.Errorf("threshold mismatch: expected %d, got %d", threshold, meta.threshold)
 	}
 
-	if recChunkCount != chunkCount {
-		t.Errorf("chunkCount mismatch: expected %d, got %d", chunkCount, recChunkCount)
+	if meta.chunkCount != chunkCount {
+		t.Errorf("chunkCount mismatch: expected %d, got %d", chunkCount, meta.chunkCount)
 	}
 
-	if len(recCommitments) != chunkCount {
+	if len(meta.commitments) != chunkCount {
 		t.Errorf("commitments count mismatch")
 	}
 }
@@ -1178,25 +1549,33 @@ func TestDeserializeMetadata_Invalid(t *testing.T) {
 	}{
 		{
 			name: "too short",
-			data: []byte{1},
+			data: []byte{2, 1},
+		},
+		{
+			name: "unknown scheme",
+			data: []byte{9, 1, 1},
 		},
 		{
 			name: "invalid threshold",
-			data: []byte{0, 1},
+			data: []byte{2, 0, 1},
 		},
 		{
 			name: "invalid chunk count",
-			data: []byte{1, 0},
+			data: []byte{2, 1, 0},
+		},
+		{
+			name: "missing share set",
+			data: []byte{2, 1, 1, 0, 0, 0},
 		},
 		{
 			name: "size mismatch",
-			data: []byte{2, 1, 0, 0, 0},
+			data: append([]byte{2, 2, 1}, make([]byte, shareSetIDSize+3)...),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, _, _, err := pvss.deserializeMetadata(tt.data)
+			_, err := pvss.deserializeMetadata(tt.data)
 			if err == nil {
 				t.Error("expected error for invalid metadata")
 			}
@@ -1204,6 +1583,14 @@ func TestDeserializeMetadata_Invalid(t *testing.T) {
 	}
 }
 
+// withShareHeader builds serialized share data with the given ID and chunk
+// count, an empty share set ID, and body following the header
+func withShareHeader(id, chunkCount byte, body ...byte) []byte {
+	data := []byte{id, chunkCount}
+	data = append(data, make([]byte, shareSetIDSize)...)
+	return append(data, body...)
+}
+
 // TestDeserializeShareData_Invalid tests invalid share data handling
 func TestDeserializeShareData_Invalid(t *testing.T) {
 	pvss := NewPedersenVSS()
@@ -1220,19 +1607,39 @@ func TestDeserializeShareData_Invalid(t *testing.T) {
 			name: "too short",
 			data: []byte{1},
 		},
+		{
+			name: "missing share set",
+			data: []byte{1, 1, 0, 0, 0},
+		},
+		{
+			name: "insufficient chunk length data",
+			data: withShareHeader(1, 2, 5), // says 2 chunks, but only provides 1 chunk length
+		},
+		{
+			name: "chunk length too large",
+			data: withShareHeader(1, 1, 33, 1, 5),
+		},
 		{
 			name: "insufficient value length data",
-			data: []byte{1, 2, 5}, // says 2 chunks, but only provides 1 length byte
+			data: withShareHeader(1, 2, 1, 1, 1, 5), // says 2 chunks, but only provides 1 value
 		},
 		{
 			name: "insufficient value data",
-			data: []byte{1, 1, 10}, // says value is 10 bytes but no data follows
+			data: withShareHeader(1, 1, 1, 10), // says value is 10 bytes but no data follows
+		},
+		{
+			name: "truncated blinding data",
+			data: withShareHeader(1, 1, 1, 1, 5, 2, 7), // blinding claims 2 bytes but only 1 follows
+		},
+		{
+			name: "trailing data",
+			data: withShareHeader(1, 1, 1, 1, 5, 1, 7, 9),
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			_, _, err := pvss.deserializeShareData(tt.data)
+			_, err := pvss.deserializeShareData(tt.data)
 			if err == nil {
 				t.Error("expected error for invalid share data")
 			}
@@ -1265,6 +1672,78 @@ func TestMaximumShares(t *testing.T) {
 	}
 }
 
+// TestMoreThan255Shares tests share IDs that no longer fit in one byte
+func TestMoreThan255Shares(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	secret := "large committee"
+	shares, err := pvss.SplitSecret(secret, 300, 3)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+
+	if len(shares) != 300 {
+		t.Fatalf("expected 300 shares, got %d", len(shares))
+	}
+
+	// Shares 256 and up would have wrapped to IDs 0 and up
+	selected := []Share{shares[255], shares[256], shares[299]}
+	for i, share := range selected {
+		if valid, err := pvss.VerifyShare(share); err != nil || !valid {
+			t.Errorf("share %d failed verification: %v", i, err)
+		}
+	}
+
+	reconstructed, err := pvss.ReconstructSecret(selected)
+	if err != nil {
+		t.Fatalf("ReconstructSecret failed: %v", err)
+	}
+	if reconstructed != secret {
+		t.Errorf("expected %q, got %q", secret, reconstructed)
+	}
+}
+
+// TestMoreThan255Chunks tests a secret longer than 255 chunks of 31 bytes
+func TestMoreThan255Chunks(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	secret := make([]byte, 256*pvss.chunkSize()+5)
+	if _, err := rand.Read(secret); err != nil {
+		t.Fatalf("rand.Read failed: %v", err)
+	}
+
+	shares, err := pvss.SplitBytes(secret, 3, 2, WithCommitmentScheme(SchemeFeldman))
+	if err != nil {
+		t.Fatalf("SplitBytes failed: %v", err)
+	}
+
+	meta, err := pvss.decodeMetadata(shares[0].KeyCheck)
+	if err != nil {
+		t.Fatalf("decodeMetadata failed: %v", err)
+	}
+	if meta.chunkCount != 257 {
+		t.Errorf("expected 257 chunks, got %d", meta.chunkCount)
+	}
+
+	reconstructed, err := pvss.ReconstructBytes(shares[1:])
+	if err != nil {
+		t.Fatalf("ReconstructBytes failed: %v", err)
+	}
+	if !bytes.Equal(reconstructed, secret) {
+		t.Error("reconstructed secret does not match")
+	}
+}
+
+// TestSplitBytes_SecretTooLarge tests the explicit size limit
+func TestSplitBytes_SecretTooLarge(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	_, err := pvss.SplitBytes(make([]byte, maxChunks*pvss.chunkSize()+1), 3, 2)
+	if err == nil || !strings.Contains(err.Error(), "exceeds the maximum") {
+		t.Errorf("expected size limit error, got %v", err)
+	}
+}
+
 // TestChunking_EdgeCases tests edge cases in chunking
 func TestChunking_EdgeCases(t *testing.T) {
 	pvss := NewPedersenVSS()
@@ -1306,31 +1785,30 @@ func TestPointSerialization_AllCurvePoints(t *testing.T) {
 	pvss := NewPedersenVSS()
 
 	// Test several different scalars
-	scalars := []*big.Int{
-		big.NewInt(1),
-		big.NewInt(42),
-		big.NewInt(12345),
-		new(big.Int).Exp(big.NewInt(2), big.NewInt(128), nil),
+	scalars := []*Scalar{
+		testScalar(1),
+		testScalar(42),
+		testScalar(12345),
+		bigScalar(t, pvss.group, new(big.Int).Exp(big.NewInt(2), big.NewInt(128), nil)),
 	}
 
 	for i, scalar := range scalars {
 		t.Run("", func(t *testing.T) {
-			x, y := pvss.curve.ScalarBaseMult(scalar.Bytes())
-			point := Point{X: x, Y: y}
+			point := pvss.group.Generator().ScalarMult(scalar)
 
-			serialized := pvss.serializeCommitment(point)
-			deserialized, err := pvss.deserializeCommitment(serialized)
+			serialized := point.Bytes()
+			deserialized, err := pvss.group.DecodeElement(serialized)
 
 			if err != nil {
 				t.Fatalf("scalar %d: deserialization failed: %v", i, err)
 			}
 
-			if deserialized.X.Cmp(point.X) != 0 || deserialized.Y.Cmp(point.Y) != 0 {
+			if !deserialized.Equal(point) {
 				t.Errorf("scalar %d: point mismatch", i)
 			}
 
 			// Verify point is on curve
-			if !pvss.curve.IsOnCurve(deserialized.X, deserialized.Y) {
+			if x, _ := elliptic.UnmarshalCompressed(elliptic.P256(), deserialized.Bytes()); x == nil {
 				t.Errorf("scalar %d: deserialized point not on curve", i)
 			}
 		})
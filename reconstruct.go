@@ -0,0 +1,234 @@
+package pvss
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+var (
+	// ErrShareMismatch marks a share whose values do not match the
+	// commitments in the metadata.
+	ErrShareMismatch = errors.New("share does not match commitments")
+	// ErrDuplicateShare marks a share whose ID was already accepted.
+	ErrDuplicateShare = errors.New("duplicate share ID")
+	// ErrChunkSizeMismatch marks a share whose recorded chunk lengths
+	// disagree with the majority of valid shares.
+	ErrChunkSizeMismatch = errors.New("chunk lengths disagree with other shares")
+	// ErrMetadataMismatch marks a share whose KeyCheck differs from the
+	// commitment set the other shares were checked against.
+	ErrMetadataMismatch = errors.New("share metadata does not match")
+)
+
+// MetadataMismatchError reports shares whose KeyCheck does not match the
+// commitment set most of the shares carry, typically because they come from
+// a different split of a rotated secret.
+type MetadataMismatchError struct {
+	Indices  []int // Positions of the mismatched shares in the input slice
+	ShareIDs []int // IDs of the mismatched shares, or 0 where undecodable
+}
+
+func (e *MetadataMismatchError) Error() string {
+	names := make([]string, len(e.Indices))
+	for i, index := range e.Indices {
+		names[i] = fmt.Sprintf("%d (ID %d)", index, e.ShareIDs[i])
+	}
+	return fmt.Sprintf("metadata mismatch: shares %s do not belong to the split most shares describe", strings.Join(names, ", "))
+}
+
+// Is reports ErrMetadataMismatch as matching, so callers can use errors.Is.
+func (e *MetadataMismatchError) Is(target error) bool {
+	return target == ErrMetadataMismatch
+}
+
+// RejectedShare describes a share that was left out of a verified
+// reconstruction.
+type RejectedShare struct {
+	Index   int   // Position of the share in the input slice
+	ShareID int   // Share ID, or 0 if the share could not be decoded
+	Reason  error // Why the share was rejected
+}
+
+// ReconstructionReport records which shares a verified reconstruction used
+// and which it threw out.
+type ReconstructionReport struct {
+	Threshold int             // Threshold recorded in the metadata
+	Accepted  []int           // IDs of the shares used for interpolation
+	Rejected  []RejectedShare // Shares that failed verification
+}
+
+// ReconstructSecretVerified is ReconstructBytesVerified for string secrets.
+func (pvss *PedersenVSS) ReconstructSecretVerified(shares []Share) (string, *ReconstructionReport, error) {
+	secret, report, err := pvss.ReconstructBytesVerified(shares)
+	if err != nil {
+		return "", report, err
+	}
+
+	return string(secret), report, nil
+}
+
+// ReconstructBytesVerified checks every share against the commitments in the
+// KeyCheck most of the shares carry, throws out the ones that fail or carry
+// different metadata, and interpolates the secret from the rest. A forged
+// KeyCheck on one share therefore cannot turn away the honest ones. The
+// report names every rejected share and why; it is returned even when too
+// few valid shares remain.
+func (pvss *PedersenVSS) ReconstructBytesVerified(shares []Share) ([]byte, *ReconstructionReport, error) {
+	if len(shares) == 0 {
+		return nil, nil, errors.New("no shares provided")
+	}
+
+	keyChecks := make([][]byte, len(shares))
+	keyCheckErrs := make([]error, len(shares))
+	for i, share := range shares {
+		keyChecks[i], keyCheckErrs[i] = pvss.decodeMetadataBytes(share.KeyCheck)
+	}
+
+	meta, matches, err := pvss.majorityMetadata(keyChecks, keyCheckErrs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	report := &ReconstructionReport{Threshold: meta.threshold}
+	reject := func(index, id int, reason error) {
+		report.Rejected = append(report.Rejected, RejectedShare{Index: index, ShareID: id, Reason: reason})
+	}
+
+	var verified []shareData
+	var indices []int
+	seen := make(map[int]bool)
+
+	for i, share := range shares {
+		data, err := pvss.decodeShareData(share.Key)
+		if err != nil {
+			reject(i, 0, err)
+			continue
+		}
+
+		if !matches[i] {
+			reject(i, data.id, ErrMetadataMismatch)
+			continue
+		}
+
+		valid, err := pvss.verifyShareData(data, meta)
+		if err != nil {
+			reject(i, data.id, err)
+			continue
+		}
+		if !valid {
+			reject(i, data.id, ErrShareMismatch)
+			continue
+		}
+
+		if seen[data.id] {
+			reject(i, data.id, ErrDuplicateShare)
+			continue
+		}
+		seen[data.id] = true
+
+		verified = append(verified, data)
+		indices = append(indices, i)
+	}
+
+	// Chunk lengths are not covered by the commitments, so they are taken
+	// from the majority of shares that passed verification
+	sizes := majoritySizes(verified)
+
+	var accepted []shareData
+	for i, data := range verified {
+		if !equalSizes(data.sizes, sizes) {
+			reject(indices[i], data.id, ErrChunkSizeMismatch)
+			continue
+		}
+
+		accepted = append(accepted, data)
+		report.Accepted = append(report.Accepted, data.id)
+	}
+
+	if len(accepted) < meta.threshold {
+		return nil, report, fmt.Errorf("insufficient valid shares: need %d, got %d", meta.threshold, len(accepted))
+	}
+
+	secret, err := pvss.interpolateChunks(accepted, meta.chunkCount)
+	if err != nil {
+		return nil, report, err
+	}
+
+	return secret, report, nil
+}
+
+// majorityMetadata parses every KeyCheck payload and picks the metadata
+// carried by the most shares, ties going to the earliest. Payloads are
+// compared by their parsed metadata rather than their bytes, so a KeyCheck
+// re-encoded in the other mnemonic mode still counts with its split. It
+// also reports which payloads match the pick. If none parse, the first
+// share's error is returned.
+func (pvss *PedersenVSS) majorityMetadata(keyChecks [][]byte, errs []error) (metadata, []bool, error) {
+	keys := make([]string, len(keyChecks))
+	counts := make(map[string]int)
+	parsed := make(map[string]metadata)
+	firstErr := errs[0]
+
+	best := ""
+	for i, keyCheck := range keyChecks {
+		if errs[i] != nil {
+			continue
+		}
+
+		meta, err := pvss.deserializeMetadata(keyCheck)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = fmt.Errorf("failed to parse metadata: %w", err)
+			}
+			continue
+		}
+
+		key := string(pvss.serializeMetadata(meta))
+		keys[i], parsed[key] = key, meta
+		counts[key]++
+		if best == "" || counts[key] > counts[best] {
+			best = key
+		}
+	}
+
+	if best == "" {
+		return metadata{}, nil, firstErr
+	}
+
+	matches := make([]bool, len(keys))
+	for i, key := range keys {
+		matches[i] = key == best
+	}
+	return parsed[best], matches, nil
+}
+
+func majoritySizes(shares []shareData) []int {
+	var best []int
+	bestCount := 0
+
+	for _, candidate := range shares {
+		count := 0
+		for _, other := range shares {
+			if equalSizes(candidate.sizes, other.sizes) {
+				count++
+			}
+		}
+		if count > bestCount {
+			best, bestCount = candidate.sizes, count
+		}
+	}
+
+	return best
+}
+
+func equalSizes(a, b []int) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
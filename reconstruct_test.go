@@ -0,0 +1,243 @@
+package pvss
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+// tamperShare returns a copy of share whose first chunk value is changed but
+// whose phrase still carries a valid checksum
+func tamperShare(t *testing.T, pvss *PedersenVSS, share Share) Share {
+	t.Helper()
+
+	data, err := pvss.decodeShareData(share.Key)
+	if err != nil {
+		t.Fatalf("decodeShareData failed: %v", err)
+	}
+	data.values[0].Add(data.values[0], pvss.field.newScalar().SetUint64(1))
+
+	mnemonic, err := pvss.mnemonicEncoder.EncodeToMnemonic(pvss.serializeShareData(data))
+	if err != nil {
+		t.Fatalf("EncodeToMnemonic failed: %v", err)
+	}
+
+	return Share{Key: pvss.mnemonicEncoder.AddChecksum(mnemonic), KeyCheck: share.KeyCheck}
+}
+
+// TestReconstructVerified_AllValid tests verified reconstruction with honest shares
+func TestReconstructVerified_AllValid(t *testing.T) {
+	pvss := NewPedersenVSS()
+	secret := "verified reconstruction"
+
+	shares, err := pvss.SplitSecret(secret, 5, 3)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+
+	reconstructed, report, err := pvss.ReconstructSecretVerified(shares)
+	if err != nil {
+		t.Fatalf("ReconstructSecretVerified failed: %v", err)
+	}
+
+	if reconstructed != secret {
+		t.Errorf("expected %q, got %q", secret, reconstructed)
+	}
+
+	if len(report.Accepted) != 5 || len(report.Rejected) != 0 {
+		t.Errorf("unexpected report: accepted=%v rejected=%v", report.Accepted, report.Rejected)
+	}
+
+	if report.Threshold != 3 {
+		t.Errorf("expected threshold 3, got %d", report.Threshold)
+	}
+}
+
+// TestReconstructVerified_RejectsCheaters tests that tampered shares are identified and skipped
+func TestReconstructVerified_RejectsCheaters(t *testing.T) {
+	schemes := []CommitmentScheme{SchemeFeldman, SchemePedersen}
+
+	for _, scheme := range schemes {
+		t.Run(scheme.String(), func(t *testing.T) {
+			pvss := NewPedersenVSS()
+			secret := "cheater detection secret"
+
+			shares, err := pvss.SplitSecret(secret, 5, 3, WithCommitmentScheme(scheme))
+			if err != nil {
+				t.Fatalf("SplitSecret failed: %v", err)
+			}
+
+			shares[1] = tamperShare(t, pvss, shares[1])
+			shares[3] = tamperShare(t, pvss, shares[3])
+
+			// Plain reconstruction silently returns the wrong secret
+			if plain, err := pvss.ReconstructSecret(shares); err == nil && plain == secret {
+				t.Fatal("expected tampered shares to corrupt unverified reconstruction")
+			}
+
+			reconstructed, report, err := pvss.ReconstructSecretVerified(shares)
+			if err != nil {
+				t.Fatalf("ReconstructSecretVerified failed: %v", err)
+			}
+
+			if reconstructed != secret {
+				t.Errorf("expected %q, got %q", secret, reconstructed)
+			}
+
+			if len(report.Rejected) != 2 {
+				t.Fatalf("expected 2 rejected shares, got %v", report.Rejected)
+			}
+
+			for i, rejected := range report.Rejected {
+				expectedID := []int{2, 4}[i]
+				if rejected.ShareID != expectedID {
+					t.Errorf("expected rejected share ID %d, got %d", expectedID, rejected.ShareID)
+				}
+				if !errors.Is(rejected.Reason, ErrShareMismatch) {
+					t.Errorf("expected ErrShareMismatch, got %v", rejected.Reason)
+				}
+			}
+		})
+	}
+}
+
+// TestReconstructVerified_MalformedAndDuplicate tests rejection reasons for unreadable and repeated shares
+func TestReconstructVerified_MalformedAndDuplicate(t *testing.T) {
+	pvss := NewPedersenVSS()
+	secret := "malformed shares"
+
+	shares, err := pvss.SplitSecret(secret, 5, 3)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+
+	words := strings.Fields(shares[0].Key)
+	words[0] = "notaword"
+	malformed := Share{Key: strings.Join(words, " "), KeyCheck: shares[0].KeyCheck}
+
+	input := []Share{shares[1], malformed, shares[2], shares[2], shares[4]}
+
+	reconstructed, report, err := pvss.ReconstructSecretVerified(input)
+	if err != nil {
+		t.Fatalf("ReconstructSecretVerified failed: %v", err)
+	}
+
+	if reconstructed != secret {
+		t.Errorf("expected %q, got %q", secret, reconstructed)
+	}
+
+	if len(report.Rejected) != 2 {
+		t.Fatalf("expected 2 rejected shares, got %v", report.Rejected)
+	}
+
+	if report.Rejected[0].Index != 1 || report.Rejected[0].ShareID != 0 {
+		t.Errorf("unexpected rejection for malformed share: %+v", report.Rejected[0])
+	}
+
+	if report.Rejected[1].Index != 3 || !errors.Is(report.Rejected[1].Reason, ErrDuplicateShare) {
+		t.Errorf("unexpected rejection for duplicate share: %+v", report.Rejected[1])
+	}
+}
+
+// TestReconstructVerified_ChunkSizeTampering tests that altered chunk lengths are outvoted
+func TestReconstructVerified_ChunkSizeTampering(t *testing.T) {
+	pvss := NewPedersenVSS()
+	secret := []byte{0, 1, 2, 3}
+
+	shares, err := pvss.SplitBytes(secret, 4, 2)
+	if err != nil {
+		t.Fatalf("SplitBytes failed: %v", err)
+	}
+
+	data, _ := pvss.decodeShareData(shares[0].Key)
+	data.sizes[0]++
+	mnemonic, _ := pvss.mnemonicEncoder.EncodeToMnemonic(pvss.serializeShareData(data))
+	shares[0].Key = pvss.mnemonicEncoder.AddChecksum(mnemonic)
+
+	reconstructed, report, err := pvss.ReconstructBytesVerified(shares)
+	if err != nil {
+		t.Fatalf("ReconstructBytesVerified failed: %v", err)
+	}
+
+	if string(reconstructed) != string(secret) {
+		t.Errorf("expected %x, got %x", secret, reconstructed)
+	}
+
+	if len(report.Rejected) != 1 || !errors.Is(report.Rejected[0].Reason, ErrChunkSizeMismatch) {
+		t.Errorf("unexpected rejections: %+v", report.Rejected)
+	}
+}
+
+// TestReconstructVerified_Insufficient tests failure when too few shares survive verification
+func TestReconstructVerified_Insufficient(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	shares, err := pvss.SplitSecret("not enough", 5, 3)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+
+	input := []Share{shares[0], tamperShare(t, pvss, shares[1]), shares[2]}
+
+	_, report, err := pvss.ReconstructSecretVerified(input)
+	if err == nil {
+		t.Fatal("expected error when fewer than threshold shares are valid")
+	}
+
+	if report == nil || len(report.Rejected) != 1 || report.Rejected[0].ShareID != 2 {
+		t.Errorf("expected report naming share 2, got %+v", report)
+	}
+
+	if _, _, err := pvss.ReconstructSecretVerified(nil); err == nil {
+		t.Error("expected error for empty shares")
+	}
+}
+
+// TestReconstructVerified_MetadataMismatch tests that shares from another split are rejected by name
+func TestReconstructVerified_MetadataMismatch(t *testing.T) {
+	pvss := NewPedersenVSS()
+	secret := "current secret"
+
+	current, _ := pvss.SplitSecret(secret, 5, 3)
+	previous, _ := pvss.SplitSecret("retired secret", 5, 3)
+
+	input := []Share{current[0], previous[1], current[2], current[3]}
+
+	reconstructed, report, err := pvss.ReconstructSecretVerified(input)
+	if err != nil {
+		t.Fatalf("ReconstructSecretVerified failed: %v", err)
+	}
+
+	if reconstructed != secret {
+		t.Errorf("expected %q, got %q", secret, reconstructed)
+	}
+
+	if len(report.Rejected) != 1 || report.Rejected[0].Index != 1 || !errors.Is(report.Rejected[0].Reason, ErrMetadataMismatch) {
+		t.Errorf("unexpected rejections: %+v", report.Rejected)
+	}
+}
+
+// TestReconstructVerified_ForgedFirstKeyCheck tests that a forged KeyCheck on
+// the first share does not become the reference for the others
+func TestReconstructVerified_ForgedFirstKeyCheck(t *testing.T) {
+	pvss := NewPedersenVSS()
+	secret := "honest majority"
+
+	shares, _ := pvss.SplitSecret(secret, 5, 3)
+	forged, _ := pvss.SplitSecret("forged", 5, 3)
+
+	input := []Share{forged[0], shares[1], shares[2], shares[3]}
+
+	reconstructed, report, err := pvss.ReconstructSecretVerified(input)
+	if err != nil {
+		t.Fatalf("ReconstructSecretVerified failed: %v", err)
+	}
+
+	if reconstructed != secret {
+		t.Errorf("expected %q, got %q", secret, reconstructed)
+	}
+
+	if len(report.Rejected) != 1 || report.Rejected[0].Index != 0 || !errors.Is(report.Rejected[0].Reason, ErrMetadataMismatch) {
+		t.Errorf("unexpected rejections: %+v", report.Rejected)
+	}
+}
@@ -0,0 +1,141 @@
+package pvss
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrLegacyShare is returned when refreshing, resharing or repairing a share
+// printed before shares recorded their share set and chunk sizes. Reconstruct
+// the secret and split it again instead.
+var ErrLegacyShare = errors.New("share predates share sets and must be split again")
+
+// DealRefresh starts a proactive refresh of the split share belongs to, as
+// described by Herzberg et al. Each dealing shares zero, so the secret is
+// unchanged. It returns a dealing to broadcast to every holder and a
+// sub-share for each recipient share ID, to be sent privately. Recipients
+// would normally be every current holder, including the dealer.
+func (pvss *PedersenVSS) DealRefresh(share Share, recipients []int) (*Dealing, map[int]*SubShare, error) {
+	data, meta, err := pvss.decodeVerifiedShare(share)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// The blinding polynomial also has a zero constant term, so the first
+	// commitment is the identity and anyone can see zero is shared
+	zeros := make([]*Scalar, meta.chunkCount)
+	for i := range zeros {
+		zeros[i] = pvss.field.newScalar()
+	}
+	var blindingZeros []*Scalar
+	if meta.scheme == SchemePedersen {
+		blindingZeros = zeros
+	}
+
+	return pvss.deal(data, meta, meta.threshold, recipients, zeros, blindingZeros)
+}
+
+// ApplyRefresh adds the sub-shares dealt to share's holder to its share
+// values and derives the new commitments from the old KeyCheck and the
+// dealings. It needs dealings from at least threshold distinct holders and
+// exactly one sub-share for each. Every holder must apply the same dealings;
+// they then all arrive at the same new KeyCheck, the secret is unchanged,
+// and shares from before the refresh no longer combine with the new ones.
+func (pvss *PedersenVSS) ApplyRefresh(share Share, dealings []*Dealing, subShares []*SubShare) (Share, error) {
+	data, meta, err := pvss.decodeVerifiedShare(share)
+	if err != nil {
+		return Share{}, err
+	}
+
+	if len(dealings) < meta.threshold {
+		return Share{}, fmt.Errorf("insufficient dealings: need %d, got %d", meta.threshold, len(dealings))
+	}
+
+	identity := pvss.group.Identity()
+	for _, dealing := range dealings {
+		if err := pvss.checkDealingLayout(dealing, meta, meta.threshold); err != nil {
+			return Share{}, err
+		}
+		for _, commitments := range dealing.meta.commitments {
+			if !commitments[0].Equal(identity) {
+				return Share{}, fmt.Errorf("%w: dealing from holder %d does not share zero", ErrInvalidDealing, dealing.dealer)
+			}
+		}
+	}
+
+	received, err := pvss.receiveSubShares(data.id, dealings, subShares)
+	if err != nil {
+		return Share{}, err
+	}
+
+	refreshed := shareData{
+		id:          data.id,
+		sizes:       data.sizes,
+		values:      make([]*Scalar, meta.chunkCount),
+		wholeScalar: meta.wholeScalar,
+	}
+	for i, value := range data.values {
+		refreshed.values[i] = pvss.field.newScalar().Set(value)
+	}
+	if data.blindings != nil {
+		refreshed.blindings = make([]*Scalar, meta.chunkCount)
+		for i, blinding := range data.blindings {
+			refreshed.blindings[i] = pvss.field.newScalar().Set(blinding)
+		}
+	}
+
+	newMeta := metadata{
+		scheme:      meta.scheme,
+		threshold:   meta.threshold,
+		chunkCount:  meta.chunkCount,
+		commitments: make([][]Element, meta.chunkCount),
+		wholeScalar: meta.wholeScalar,
+	}
+	for i, commitments := range meta.commitments {
+		newMeta.commitments[i] = append([]Element{}, commitments...)
+	}
+
+	for d, dealing := range dealings {
+		sub := received[d]
+		for i := range refreshed.values {
+			refreshed.values[i].Add(refreshed.values[i], sub.data.values[i])
+			if refreshed.blindings != nil {
+				refreshed.blindings[i].Add(refreshed.blindings[i], sub.data.blindings[i])
+			}
+			for k, commitment := range dealing.meta.commitments[i] {
+				newMeta.commitments[i][k] = newMeta.commitments[i][k].Add(commitment)
+			}
+		}
+	}
+
+	newMeta.set = derivedShareSetID(meta.set, pvss.serializeCommitments(newMeta), latestDealing(dealings))
+	refreshed.set = newMeta.set
+
+	return pvss.encodeShare(refreshed, newMeta)
+}
+
+// decodeVerifiedShare decodes a share and checks it against its KeyCheck.
+func (pvss *PedersenVSS) decodeVerifiedShare(share Share) (shareData, metadata, error) {
+	data, err := pvss.decodeShareData(share.Key)
+	if err != nil {
+		return shareData{}, metadata{}, err
+	}
+	if data.set == (ShareSetID{}) {
+		return shareData{}, metadata{}, ErrLegacyShare
+	}
+
+	meta, err := pvss.decodeMetadata(share.KeyCheck)
+	if err != nil {
+		return shareData{}, metadata{}, err
+	}
+
+	valid, err := pvss.verifyShareData(data, meta)
+	if err != nil {
+		return shareData{}, metadata{}, err
+	}
+	if !valid {
+		return shareData{}, metadata{}, ErrShareMismatch
+	}
+
+	return data, meta, nil
+}
@@ -0,0 +1,244 @@
+package pvss
+
+import (
+	"errors"
+	"testing"
+)
+
+// refreshAll runs a refresh in which the holders of shares[:dealers] deal
+// to every holder, passing each message through its encoding
+func refreshAll(t *testing.T, pvss *PedersenVSS, shares []Share, dealers int) []Share {
+	t.Helper()
+
+	recipients := make([]int, len(shares))
+	for i := range shares {
+		recipients[i] = i + 1
+	}
+
+	var dealings []*Dealing
+	inbox := make(map[int][]*SubShare)
+
+	for _, share := range shares[:dealers] {
+		dealing, subShares, err := pvss.DealRefresh(share, recipients)
+		if err != nil {
+			t.Fatalf("DealRefresh failed: %v", err)
+		}
+
+		parsed, err := pvss.ParseDealing(dealing.Bytes())
+		if err != nil {
+			t.Fatalf("ParseDealing failed: %v", err)
+		}
+		dealings = append(dealings, parsed)
+
+		for id, sub := range subShares {
+			parsed, err := pvss.ParseSubShare(sub.Bytes())
+			if err != nil {
+				t.Fatalf("ParseSubShare failed: %v", err)
+			}
+			inbox[id] = append(inbox[id], parsed)
+		}
+	}
+
+	refreshed := make([]Share, len(shares))
+	for i, share := range shares {
+		var err error
+		refreshed[i], err = pvss.ApplyRefresh(share, dealings, inbox[i+1])
+		if err != nil {
+			t.Fatalf("ApplyRefresh for share %d failed: %v", i+1, err)
+		}
+	}
+
+	return refreshed
+}
+
+// TestRefresh_PreservesSecret tests that refreshed shares verify, agree on
+// their KeyCheck and reconstruct the original secret
+func TestRefresh_PreservesSecret(t *testing.T) {
+	secret := "rotate the custodians, keep the secret"
+
+	for _, group := range []Group{P256(), Ristretto255()} {
+		for _, scheme := range []CommitmentScheme{SchemePedersen, SchemeFeldman} {
+			t.Run(group.Name()+"/"+scheme.String(), func(t *testing.T) {
+				pvss := NewPedersenVSS(WithGroup(group))
+
+				shares, err := pvss.SplitSecret(secret, 5, 3, WithCommitmentScheme(scheme))
+				if err != nil {
+					t.Fatalf("SplitSecret failed: %v", err)
+				}
+
+				refreshed := refreshAll(t, pvss, shares, 3)
+
+				for i, share := range refreshed {
+					if share.Key == shares[i].Key {
+						t.Errorf("share %d: Key unchanged by refresh", i+1)
+					}
+					if share.KeyCheck != refreshed[0].KeyCheck {
+						t.Errorf("share %d: holders derived different KeyChecks", i+1)
+					}
+
+					valid, err := pvss.VerifyShare(share)
+					if err != nil || !valid {
+						t.Errorf("share %d: refreshed share failed verification: %v", i+1, err)
+					}
+				}
+
+				reconstructed, err := pvss.ReconstructSecret(refreshed[2:])
+				if err != nil {
+					t.Fatalf("ReconstructSecret failed: %v", err)
+				}
+				if reconstructed != secret {
+					t.Errorf("expected %q, got %q", secret, reconstructed)
+				}
+
+				// A second refresh builds on the first
+				again := refreshAll(t, pvss, refreshed, 4)
+				reconstructed, err = pvss.ReconstructSecret(again[:3])
+				if err != nil || reconstructed != secret {
+					t.Errorf("second refresh: got %q, %v", reconstructed, err)
+				}
+			})
+		}
+	}
+}
+
+// TestRefresh_OldSharesUseless tests that old shares do not combine with
+// refreshed ones
+func TestRefresh_OldSharesUseless(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	shares, err := pvss.SplitSecret("leaked before the refresh", 5, 3)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+	refreshed := refreshAll(t, pvss, shares, 3)
+
+	mixed := []Share{shares[0], shares[1], refreshed[2]}
+	if _, err := pvss.ReconstructSecret(mixed); !errors.Is(err, ErrMetadataMismatch) {
+		t.Errorf("expected ErrMetadataMismatch, got %v", err)
+	}
+
+	// Even under the new KeyCheck, the old values do not verify
+	stale := Share{Key: shares[0].Key, KeyCheck: refreshed[0].KeyCheck}
+	if valid, _ := pvss.VerifyShare(stale); valid {
+		t.Error("old share verified against the refreshed KeyCheck")
+	}
+}
+
+// TestRefresh_Rejects tests that bad dealings and sub-shares are refused
+func TestRefresh_Rejects(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	shares, err := pvss.SplitSecret("refresh rejects", 4, 2)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+	recipients := []int{1, 2, 3, 4}
+
+	deal := func(share Share) (*Dealing, map[int]*SubShare) {
+		dealing, subShares, err := pvss.DealRefresh(share, recipients)
+		if err != nil {
+			t.Fatalf("DealRefresh failed: %v", err)
+		}
+		return dealing, subShares
+	}
+	d1, s1 := deal(shares[0])
+	d2, s2 := deal(shares[1])
+
+	t.Run("valid", func(t *testing.T) {
+		if _, err := pvss.ApplyRefresh(shares[2], []*Dealing{d1, d2}, []*SubShare{s1[3], s2[3]}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("too few dealings", func(t *testing.T) {
+		if _, err := pvss.ApplyRefresh(shares[2], []*Dealing{d1}, []*SubShare{s1[3]}); err == nil {
+			t.Error("expected error for fewer dealings than the threshold")
+		}
+	})
+
+	t.Run("missing sub-share", func(t *testing.T) {
+		_, err := pvss.ApplyRefresh(shares[2], []*Dealing{d1, d2}, []*SubShare{s1[3]})
+		if !errors.Is(err, ErrInvalidDealing) {
+			t.Errorf("expected ErrInvalidDealing, got %v", err)
+		}
+	})
+
+	t.Run("wrong recipient", func(t *testing.T) {
+		_, err := pvss.ApplyRefresh(shares[2], []*Dealing{d1, d2}, []*SubShare{s1[3], s2[4]})
+		if !errors.Is(err, ErrInvalidDealing) {
+			t.Errorf("expected ErrInvalidDealing, got %v", err)
+		}
+	})
+
+	t.Run("duplicate dealing", func(t *testing.T) {
+		_, err := pvss.ApplyRefresh(shares[2], []*Dealing{d1, d1}, []*SubShare{s1[3], s1[3]})
+		if !errors.Is(err, ErrInvalidDealing) {
+			t.Errorf("expected ErrInvalidDealing, got %v", err)
+		}
+	})
+
+	t.Run("tampered sub-share", func(t *testing.T) {
+		tampered := *s2[3]
+		tampered.data.values = append([]*Scalar{}, s2[3].data.values...)
+		tampered.data.values[0] = NewScalar(P256()).Add(tampered.data.values[0], testScalar(1))
+
+		_, err := pvss.ApplyRefresh(shares[2], []*Dealing{d1, d2}, []*SubShare{s1[3], &tampered})
+		if !errors.Is(err, ErrShareMismatch) {
+			t.Errorf("expected ErrShareMismatch, got %v", err)
+		}
+	})
+
+	t.Run("nonzero dealing", func(t *testing.T) {
+		// A dealer adding to the secret commits to a nonzero constant
+		coefficients := []*Scalar{testScalar(1), testScalar(2)}
+		blindings := []*Scalar{testScalar(0), testScalar(3)}
+		commitments, err := pvss.generateCommitments(coefficients, blindings)
+		if err != nil {
+			t.Fatal(err)
+		}
+		bad := *d2
+		bad.meta.commitments = [][]Element{commitments}
+		sub := &SubShare{dealer: 2, data: shareData{
+			id:        3,
+			set:       d2.meta.set,
+			values:    []*Scalar{pvss.evaluatePolynomial(coefficients, 3)},
+			blindings: []*Scalar{pvss.evaluatePolynomial(blindings, 3)},
+		}}
+
+		_, err = pvss.ApplyRefresh(shares[2], []*Dealing{d1, &bad}, []*SubShare{s1[3], sub})
+		if !errors.Is(err, ErrInvalidDealing) {
+			t.Errorf("expected ErrInvalidDealing, got %v", err)
+		}
+	})
+
+	t.Run("other split", func(t *testing.T) {
+		other, err := pvss.SplitSecret("refresh rejects", 4, 2)
+		if err != nil {
+			t.Fatalf("SplitSecret failed: %v", err)
+		}
+		_, err = pvss.ApplyRefresh(other[2], []*Dealing{d1, d2}, []*SubShare{s1[3], s2[3]})
+		if !errors.Is(err, ErrInvalidDealing) {
+			t.Errorf("expected ErrInvalidDealing, got %v", err)
+		}
+	})
+
+	t.Run("bad recipients", func(t *testing.T) {
+		if _, _, err := pvss.DealRefresh(shares[0], []int{1, 1}); err == nil {
+			t.Error("expected error for duplicate recipients")
+		}
+		if _, _, err := pvss.DealRefresh(shares[0], []int{0}); err == nil {
+			t.Error("expected error for share ID 0")
+		}
+	})
+
+	t.Run("truncated encoding", func(t *testing.T) {
+		encoded := d1.Bytes()
+		if _, err := pvss.ParseDealing(encoded[:len(encoded)-1]); err == nil {
+			t.Error("expected error for truncated dealing")
+		}
+		encoded = s1[1].Bytes()
+		if _, err := pvss.ParseSubShare(encoded[:len(encoded)-1]); err == nil {
+			t.Error("expected error for truncated sub-share")
+		}
+	})
+}
@@ -0,0 +1,405 @@
+package pvss
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+)
+
+// repairMessage is what both rounds of a repair send: values summing, over
+// the helpers, to the repaired share's values.
+type repairMessage struct {
+	helper  int
+	target  int
+	helpers []int     // Share IDs of every helper, in the order they agreed
+	data    shareData // data.id is the share ID the message is for
+	raw     []byte
+}
+
+// Helper returns the share ID of the holder that sent the message.
+func (m *repairMessage) Helper() int {
+	return m.helper
+}
+
+// Target returns the share ID being repaired or enrolled.
+func (m *repairMessage) Target() int {
+	return m.target
+}
+
+// Bytes returns the message's encoding.
+func (m *repairMessage) Bytes() []byte {
+	return append([]byte{}, m.raw...)
+}
+
+// RepairMask is the first round of a repair: one random piece of a
+// helper's contribution to the repaired share, sent privately to another
+// helper. On its own it is uniformly random. Its encoding is read by
+// ParseRepairMask.
+type RepairMask struct {
+	repairMessage
+}
+
+// Recipient returns the share ID of the helper the mask is for.
+func (m *RepairMask) Recipient() int {
+	return m.data.id
+}
+
+// RepairPartial is the second round of a repair: the sum of the masks one
+// helper received, sent privately to the holder of the repaired share. Its
+// encoding is read by ParseRepairPartial.
+type RepairPartial struct {
+	repairMessage
+}
+
+// DealRepair starts rebuilding the share with ID target, either one that
+// was lost or a new one, without reconstructing the secret. helpers lists
+// the share IDs of at least threshold holders taking part, share's holder
+// among them. The holder's Lagrange term for target is split into random
+// masks, one for each helper, to be sent privately. No helper learns
+// another's share, and the new holder learns only its own.
+func (pvss *PedersenVSS) DealRepair(share Share, target int, helpers []int) (map[int]*RepairMask, error) {
+	data, meta, err := pvss.decodeVerifiedShare(share)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkRepairHelpers(meta, data.id, target, helpers); err != nil {
+		return nil, err
+	}
+
+	coefficients, err := pvss.lagrangeCoefficientsAt(helpers, target)
+	if err != nil {
+		return nil, err
+	}
+	coefficient := coefficients[slices.Index(helpers, data.id)]
+
+	masks := make(map[int]*RepairMask, len(helpers))
+	for _, id := range helpers {
+		mask := &RepairMask{repairMessage{
+			helper:  data.id,
+			target:  target,
+			helpers: helpers,
+			data:    shareData{id: id, set: meta.set, sizes: data.sizes, values: make([]*Scalar, meta.chunkCount), wholeScalar: meta.wholeScalar},
+		}}
+		if data.blindings != nil {
+			mask.data.blindings = make([]*Scalar, meta.chunkCount)
+		}
+		masks[id] = mask
+	}
+
+	for chunkIdx := 0; chunkIdx < meta.chunkCount; chunkIdx++ {
+		term := pvss.field.newScalar().Multiply(coefficient, data.values[chunkIdx])
+		pieces, err := pvss.splitAdditive(term, helpers)
+		if err != nil {
+			return nil, err
+		}
+		for id, piece := range pieces {
+			masks[id].data.values[chunkIdx] = piece
+		}
+
+		if data.blindings != nil {
+			term = pvss.field.newScalar().Multiply(coefficient, data.blindings[chunkIdx])
+			pieces, err = pvss.splitAdditive(term, helpers)
+			if err != nil {
+				return nil, err
+			}
+			for id, piece := range pieces {
+				masks[id].data.blindings[chunkIdx] = piece
+			}
+		}
+	}
+
+	for _, mask := range masks {
+		mask.raw = pvss.serializeRepairMessage(maskMagic, &mask.repairMessage)
+	}
+
+	return masks, nil
+}
+
+// CombineRepairMasks sums the masks the other helpers, and share's holder
+// itself, sent to it for the repair of target. It needs exactly one mask
+// from every helper. The result goes privately to the holder of the
+// repaired share.
+func (pvss *PedersenVSS) CombineRepairMasks(share Share, target int, helpers []int, masks []*RepairMask) (*RepairPartial, error) {
+	data, meta, err := pvss.decodeVerifiedShare(share)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := checkRepairHelpers(meta, data.id, target, helpers); err != nil {
+		return nil, err
+	}
+
+	messages := make([]*repairMessage, len(masks))
+	for i, mask := range masks {
+		if mask.data.id != data.id {
+			return nil, fmt.Errorf("%w: mask from holder %d is for share %d, not %d", ErrInvalidDealing, mask.helper, mask.data.id, data.id)
+		}
+		messages[i] = &mask.repairMessage
+	}
+
+	sum, err := pvss.sumRepairMessages(meta, target, helpers, messages)
+	if err != nil {
+		return nil, err
+	}
+	if !equalSizes(sum.sizes, data.sizes) {
+		return nil, fmt.Errorf("%w: masks disagree on chunk lengths", ErrInvalidDealing)
+	}
+
+	sum.id = target
+	partial := &RepairPartial{repairMessage{
+		helper:  data.id,
+		target:  target,
+		helpers: helpers,
+		data:    sum,
+	}}
+	partial.raw = pvss.serializeRepairMessage(partialMagic, &partial.repairMessage)
+
+	return partial, nil
+}
+
+// CompleteRepair sums the partials from every helper into the share with
+// ID target. The share is checked against keyCheck, the KeyCheck of the
+// split being repaired, and carries it unchanged.
+func (pvss *PedersenVSS) CompleteRepair(keyCheck string, target int, partials []*RepairPartial) (Share, error) {
+	meta, err := pvss.decodeMetadata(keyCheck)
+	if err != nil {
+		return Share{}, err
+	}
+
+	if len(partials) == 0 {
+		return Share{}, errors.New("no partials provided")
+	}
+	helpers := partials[0].helpers
+	if len(helpers) < meta.threshold {
+		return Share{}, fmt.Errorf("insufficient helpers: need %d, got %d", meta.threshold, len(helpers))
+	}
+
+	messages := make([]*repairMessage, len(partials))
+	for i, partial := range partials {
+		if partial.data.id != target {
+			return Share{}, fmt.Errorf("%w: partial from holder %d is for share %d, not %d", ErrInvalidDealing, partial.helper, partial.data.id, target)
+		}
+		messages[i] = &partial.repairMessage
+	}
+
+	repaired, err := pvss.sumRepairMessages(meta, target, helpers, messages)
+	if err != nil {
+		return Share{}, err
+	}
+
+	valid, err := pvss.verifyShareData(repaired, meta)
+	if err != nil {
+		return Share{}, err
+	}
+	if !valid {
+		return Share{}, ErrShareMismatch
+	}
+
+	key, err := pvss.encodePhrase(pvss.serializeShareData(repaired))
+	if err != nil {
+		return Share{}, err
+	}
+
+	return Share{Key: key, KeyCheck: keyCheck}, nil
+}
+
+// checkRepairHelpers validates the helpers of a repair of target in which
+// the holder of share ID self takes part.
+func checkRepairHelpers(meta metadata, self, target int, helpers []int) error {
+	if len(helpers) < meta.threshold {
+		return fmt.Errorf("insufficient helpers: need %d, got %d", meta.threshold, len(helpers))
+	}
+	if target < 1 || target > maxShares {
+		return fmt.Errorf("invalid target share ID: %d", target)
+	}
+
+	seen := make(map[int]bool)
+	for _, id := range helpers {
+		if id < 1 || id > maxShares {
+			return fmt.Errorf("invalid helper share ID: %d", id)
+		}
+		if seen[id] {
+			return fmt.Errorf("duplicate helper share ID: %d", id)
+		}
+		seen[id] = true
+	}
+	if seen[target] {
+		return fmt.Errorf("target share ID %d is one of the helpers", target)
+	}
+	if !seen[self] {
+		return fmt.Errorf("share %d is not one of the helpers", self)
+	}
+
+	return nil
+}
+
+// splitAdditive splits value into random pieces, one for each ID, that sum
+// to it.
+func (pvss *PedersenVSS) splitAdditive(value *Scalar, ids []int) (map[int]*Scalar, error) {
+	pieces := make(map[int]*Scalar, len(ids))
+	last := pvss.field.newScalar().Set(value)
+
+	for _, id := range ids[1:] {
+		piece, err := pvss.randomScalar()
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate mask: %v", err)
+		}
+		pieces[id] = piece
+		last.Subtract(last, piece)
+	}
+	pieces[ids[0]] = last
+
+	return pieces, nil
+}
+
+// sumRepairMessages checks that messages hold exactly one message from each
+// helper, all for the same repair of meta's split, and sums their values.
+func (pvss *PedersenVSS) sumRepairMessages(meta metadata, target int, helpers []int, messages []*repairMessage) (shareData, error) {
+	if len(messages) == 0 {
+		return shareData{}, fmt.Errorf("%w: no messages provided", ErrInvalidDealing)
+	}
+
+	received := make(map[int]*repairMessage)
+	for _, msg := range messages {
+		if msg.target != target || !slices.Equal(msg.helpers, helpers) {
+			return shareData{}, fmt.Errorf("%w: message from holder %d is for another repair", ErrInvalidDealing, msg.helper)
+		}
+		if msg.data.set != meta.set {
+			return shareData{}, fmt.Errorf("%w: message from holder %d is for share set %s, not %s", ErrInvalidDealing, msg.helper, msg.data.set, meta.set)
+		}
+		if schemeOf(msg.data) != meta.scheme || len(msg.data.values) != meta.chunkCount || msg.data.wholeScalar != meta.wholeScalar {
+			return shareData{}, fmt.Errorf("%w: message from holder %d does not match the split layout", ErrInvalidDealing, msg.helper)
+		}
+		if received[msg.helper] != nil {
+			return shareData{}, fmt.Errorf("%w: several messages from holder %d", ErrInvalidDealing, msg.helper)
+		}
+		received[msg.helper] = msg
+	}
+
+	sum := shareData{
+		id:          messages[0].data.id,
+		set:         meta.set,
+		sizes:       messages[0].data.sizes,
+		values:      make([]*Scalar, meta.chunkCount),
+		wholeScalar: meta.wholeScalar,
+	}
+	if meta.scheme == SchemePedersen {
+		sum.blindings = make([]*Scalar, meta.chunkCount)
+	}
+	for i := range sum.values {
+		sum.values[i] = pvss.field.newScalar()
+		if sum.blindings != nil {
+			sum.blindings[i] = pvss.field.newScalar()
+		}
+	}
+
+	for _, id := range helpers {
+		msg := received[id]
+		if msg == nil {
+			return shareData{}, fmt.Errorf("%w: no message from holder %d", ErrInvalidDealing, id)
+		}
+		if !equalSizes(msg.data.sizes, sum.sizes) {
+			return shareData{}, fmt.Errorf("%w: message from holder %d disagrees on chunk lengths", ErrInvalidDealing, id)
+		}
+		delete(received, id)
+
+		for i := range sum.values {
+			sum.values[i].Add(sum.values[i], msg.data.values[i])
+			if sum.blindings != nil {
+				sum.blindings[i].Add(sum.blindings[i], msg.data.blindings[i])
+			}
+		}
+	}
+	if len(received) != 0 {
+		return shareData{}, fmt.Errorf("%w: messages from holders outside the repair", ErrInvalidDealing)
+	}
+
+	return sum, nil
+}
+
+// serializeRepairMessage writes [header][helper][recipient][target]
+// [helper count][helpers][chunk count][share set ID][chunk sizes][values]
+// [blindings].
+func (pvss *PedersenVSS) serializeRepairMessage(magic byte, msg *repairMessage) []byte {
+	result := appendHeader(nil, magic, pvss.group.ID(), schemeOf(msg.data), scalarFlags(msg.data.wholeScalar))
+	result = appendCount(result, msg.helper)
+	result = appendCount(result, msg.data.id)
+	result = appendCount(result, msg.target)
+	result = appendCount(result, len(msg.helpers))
+	for _, id := range msg.helpers {
+		result = appendCount(result, id)
+	}
+	result = appendCount(result, len(msg.data.values))
+	result = appendShareSetID(result, msg.data.set)
+
+	return appendChunkValues(result, msg.data)
+}
+
+// ParseRepairMask decodes a mask produced by RepairMask.Bytes.
+func (pvss *PedersenVSS) ParseRepairMask(data []byte) (*RepairMask, error) {
+	msg, err := pvss.parseRepairMessage(data, maskMagic)
+	if err != nil {
+		return nil, err
+	}
+	return &RepairMask{*msg}, nil
+}
+
+// ParseRepairPartial decodes a partial produced by RepairPartial.Bytes.
+func (pvss *PedersenVSS) ParseRepairPartial(data []byte) (*RepairPartial, error) {
+	msg, err := pvss.parseRepairMessage(data, partialMagic)
+	if err != nil {
+		return nil, err
+	}
+	return &RepairPartial{*msg}, nil
+}
+
+func (pvss *PedersenVSS) parseRepairMessage(data []byte, magic byte) (*repairMessage, error) {
+	header, offset, err := readHeader(data, magic, pvss.group.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &repairMessage{}
+	fields := []*int{&msg.helper, &msg.data.id, &msg.target}
+	for _, field := range fields {
+		*field, offset, err = readCount(data, offset)
+		if err != nil {
+			return nil, errors.New("insufficient repair data")
+		}
+	}
+
+	helperCount, offset, err := readCount(data, offset)
+	if err != nil || helperCount > len(data)-offset {
+		return nil, errors.New("insufficient repair data")
+	}
+	msg.helpers = make([]int, helperCount)
+	for i := range msg.helpers {
+		msg.helpers[i], offset, err = readCount(data, offset)
+		if err != nil {
+			return nil, errors.New("insufficient repair data")
+		}
+	}
+
+	chunkCount, offset, err := readCount(data, offset)
+	if err != nil {
+		return nil, errors.New("insufficient repair data")
+	}
+
+	msg.data.set, offset, err = readShareSetID(data, offset)
+	if err != nil {
+		return nil, err
+	}
+
+	offset, err = pvss.readChunkValues(data, offset, chunkCount, header, &msg.data)
+	if err != nil {
+		return nil, err
+	}
+
+	if offset != len(data) {
+		return nil, errors.New("trailing repair data")
+	}
+
+	msg.raw = append([]byte{}, data...)
+	return msg, nil
+}
@@ -0,0 +1,220 @@
+package pvss
+
+import (
+	"errors"
+	"testing"
+)
+
+// repair rebuilds share target with the holders of shares as helpers,
+// passing every message through its encoding
+func repair(t *testing.T, pvss *PedersenVSS, shares []Share, target int) (Share, error) {
+	t.Helper()
+
+	helpers := make([]int, len(shares))
+	for i, share := range shares {
+		data, err := pvss.decodeShareData(share.Key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		helpers[i] = data.id
+	}
+
+	inbox := make(map[int][]*RepairMask)
+	for _, share := range shares {
+		masks, err := pvss.DealRepair(share, target, helpers)
+		if err != nil {
+			t.Fatalf("DealRepair failed: %v", err)
+		}
+		for id, mask := range masks {
+			parsed, err := pvss.ParseRepairMask(mask.Bytes())
+			if err != nil {
+				t.Fatalf("ParseRepairMask failed: %v", err)
+			}
+			inbox[id] = append(inbox[id], parsed)
+		}
+	}
+
+	var partials []*RepairPartial
+	for i, share := range shares {
+		partial, err := pvss.CombineRepairMasks(share, target, helpers, inbox[helpers[i]])
+		if err != nil {
+			t.Fatalf("CombineRepairMasks failed: %v", err)
+		}
+		parsed, err := pvss.ParseRepairPartial(partial.Bytes())
+		if err != nil {
+			t.Fatalf("ParseRepairPartial failed: %v", err)
+		}
+		partials = append(partials, parsed)
+	}
+
+	return pvss.CompleteRepair(shares[0].KeyCheck, target, partials)
+}
+
+// TestRepair_LostShare tests that a lost share is rebuilt exactly
+func TestRepair_LostShare(t *testing.T) {
+	for _, group := range []Group{P256(), Ristretto255()} {
+		for _, scheme := range []CommitmentScheme{SchemePedersen, SchemeFeldman} {
+			t.Run(group.Name()+"/"+scheme.String(), func(t *testing.T) {
+				pvss := NewPedersenVSS(WithGroup(group))
+
+				shares, err := pvss.SplitSecret("the custodian lost their paper", 5, 3, WithCommitmentScheme(scheme))
+				if err != nil {
+					t.Fatalf("SplitSecret failed: %v", err)
+				}
+
+				repaired, err := repair(t, pvss, []Share{shares[0], shares[2], shares[4]}, 2)
+				if err != nil {
+					t.Fatalf("repair failed: %v", err)
+				}
+
+				if repaired != shares[1] {
+					t.Error("repaired share differs from the lost one")
+				}
+			})
+		}
+	}
+}
+
+// TestRepair_Enroll tests that a share for a new ID verifies and combines
+// with the existing ones
+func TestRepair_Enroll(t *testing.T) {
+	secret := "welcome to the new custodian"
+	pvss := NewPedersenVSS()
+
+	shares, err := pvss.SplitSecret(secret, 5, 3)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+
+	// More helpers than the threshold work as well
+	enrolled, err := repair(t, pvss, shares[:4], maxShares)
+	if err != nil {
+		t.Fatalf("repair failed: %v", err)
+	}
+
+	valid, err := pvss.VerifyShare(enrolled)
+	if err != nil || !valid {
+		t.Errorf("enrolled share failed verification: %v", err)
+	}
+
+	reconstructed, err := pvss.ReconstructSecret([]Share{enrolled, shares[1], shares[4]})
+	if err != nil {
+		t.Fatalf("ReconstructSecret failed: %v", err)
+	}
+	if reconstructed != secret {
+		t.Errorf("expected %q, got %q", secret, reconstructed)
+	}
+}
+
+// TestRepair_Rejects tests that bad helpers and messages are refused
+func TestRepair_Rejects(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	shares, err := pvss.SplitSecret("repair rejects", 4, 2)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+	helpers := []int{1, 2}
+
+	t.Run("bad helpers", func(t *testing.T) {
+		for _, tc := range []struct {
+			name    string
+			target  int
+			helpers []int
+		}{
+			{"too few", 3, []int{1}},
+			{"target helps", 2, []int{1, 2}},
+			{"not a helper", 3, []int{2, 4}},
+			{"duplicate", 3, []int{1, 1}},
+			{"zero target", 0, []int{1, 2}},
+		} {
+			if _, err := pvss.DealRepair(shares[0], tc.target, tc.helpers); err == nil {
+				t.Errorf("%s: expected error", tc.name)
+			}
+		}
+	})
+
+	m1, err := pvss.DealRepair(shares[0], 3, helpers)
+	if err != nil {
+		t.Fatalf("DealRepair failed: %v", err)
+	}
+	m2, err := pvss.DealRepair(shares[1], 3, helpers)
+	if err != nil {
+		t.Fatalf("DealRepair failed: %v", err)
+	}
+
+	t.Run("missing mask", func(t *testing.T) {
+		_, err := pvss.CombineRepairMasks(shares[0], 3, helpers, []*RepairMask{m1[1]})
+		if !errors.Is(err, ErrInvalidDealing) {
+			t.Errorf("expected ErrInvalidDealing, got %v", err)
+		}
+	})
+
+	t.Run("wrong recipient", func(t *testing.T) {
+		_, err := pvss.CombineRepairMasks(shares[0], 3, helpers, []*RepairMask{m1[1], m2[2]})
+		if !errors.Is(err, ErrInvalidDealing) {
+			t.Errorf("expected ErrInvalidDealing, got %v", err)
+		}
+	})
+
+	t.Run("other target", func(t *testing.T) {
+		_, err := pvss.CombineRepairMasks(shares[0], 4, helpers, []*RepairMask{m1[1], m2[1]})
+		if !errors.Is(err, ErrInvalidDealing) {
+			t.Errorf("expected ErrInvalidDealing, got %v", err)
+		}
+	})
+
+	p1, err := pvss.CombineRepairMasks(shares[0], 3, helpers, []*RepairMask{m1[1], m2[1]})
+	if err != nil {
+		t.Fatalf("CombineRepairMasks failed: %v", err)
+	}
+	p2, err := pvss.CombineRepairMasks(shares[1], 3, helpers, []*RepairMask{m1[2], m2[2]})
+	if err != nil {
+		t.Fatalf("CombineRepairMasks failed: %v", err)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		if _, err := pvss.CompleteRepair(shares[0].KeyCheck, 3, []*RepairPartial{p1, p2}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("missing partial", func(t *testing.T) {
+		_, err := pvss.CompleteRepair(shares[0].KeyCheck, 3, []*RepairPartial{p1})
+		if !errors.Is(err, ErrInvalidDealing) {
+			t.Errorf("expected ErrInvalidDealing, got %v", err)
+		}
+	})
+
+	t.Run("tampered partial", func(t *testing.T) {
+		tampered := *p2
+		tampered.data.values = append([]*Scalar{}, p2.data.values...)
+		tampered.data.values[0] = NewScalar(P256()).Add(tampered.data.values[0], testScalar(1))
+
+		_, err := pvss.CompleteRepair(shares[0].KeyCheck, 3, []*RepairPartial{p1, &tampered})
+		if !errors.Is(err, ErrShareMismatch) {
+			t.Errorf("expected ErrShareMismatch, got %v", err)
+		}
+	})
+
+	t.Run("other split", func(t *testing.T) {
+		other, err := pvss.SplitSecret("repair rejects", 4, 2)
+		if err != nil {
+			t.Fatalf("SplitSecret failed: %v", err)
+		}
+		_, err = pvss.CompleteRepair(other[0].KeyCheck, 3, []*RepairPartial{p1, p2})
+		if !errors.Is(err, ErrInvalidDealing) {
+			t.Errorf("expected ErrInvalidDealing, got %v", err)
+		}
+	})
+
+	t.Run("truncated encoding", func(t *testing.T) {
+		encoded := m1[2].Bytes()
+		if _, err := pvss.ParseRepairMask(encoded[:len(encoded)-1]); err == nil {
+			t.Error("expected error for truncated mask")
+		}
+		if _, err := pvss.ParseRepairPartial(encoded); err == nil {
+			t.Error("expected error for a mask parsed as a partial")
+		}
+	})
+}
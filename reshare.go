@@ -0,0 +1,161 @@
+package pvss
+
+import (
+	"errors"
+	"fmt"
+)
+
+// DealReshare starts moving the secret of share's split to a new committee
+// with the given threshold. The holder shares its own share value with a
+// fresh polynomial of the new threshold, committing to it so the new
+// holders can check the dealing against the old KeyCheck. It returns a
+// dealing to broadcast to the new committee and a sub-share for each new
+// share ID, to be sent privately. At least the old threshold of holders
+// must deal.
+func (pvss *PedersenVSS) DealReshare(share Share, threshold int, recipients []int) (*Dealing, map[int]*SubShare, error) {
+	data, meta, err := pvss.decodeVerifiedShare(share)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if threshold < 1 {
+		return nil, nil, errors.New("threshold must be at least 1")
+	}
+	if threshold > len(recipients) {
+		return nil, nil, errors.New("threshold cannot be greater than number of recipients")
+	}
+
+	return pvss.deal(data, meta, threshold, recipients, data.values, data.blindings)
+}
+
+// CombineReshare builds the new share with the given ID from the dealings
+// of old holders and the sub-shares they sent to it. keyCheck is the old
+// KeyCheck; every dealing must commit to its dealer's share under it. The
+// sub-shares are combined with the Lagrange coefficients of the dealers'
+// share IDs, and the new commitments are derived from the dealings in the
+// same way. Every new holder must combine the same dealings; they then all
+// arrive at the same new KeyCheck, which VerifySameSecret confirms against
+// the old one.
+func (pvss *PedersenVSS) CombineReshare(keyCheck string, recipient int, dealings []*Dealing, subShares []*SubShare) (Share, error) {
+	meta, err := pvss.decodeMetadata(keyCheck)
+	if err != nil {
+		return Share{}, err
+	}
+
+	if recipient < 1 || recipient > maxShares {
+		return Share{}, fmt.Errorf("invalid recipient share ID: %d", recipient)
+	}
+	if len(dealings) < meta.threshold {
+		return Share{}, fmt.Errorf("insufficient dealings: need %d, got %d", meta.threshold, len(dealings))
+	}
+
+	threshold := dealings[0].meta.threshold
+	dealers := make([]int, len(dealings))
+	for i, dealing := range dealings {
+		if err := pvss.checkDealingLayout(dealing, meta, threshold); err != nil {
+			return Share{}, err
+		}
+		if dealing.dealer < 1 {
+			return Share{}, fmt.Errorf("%w: invalid dealer share ID %d", ErrInvalidDealing, dealing.dealer)
+		}
+
+		// The constant term must be the dealer's old share value
+		for chunkIdx, commitments := range dealing.meta.commitments {
+			if !commitments[0].Equal(pvss.evaluateCommitments(meta.commitments[chunkIdx], dealing.dealer)) {
+				return Share{}, fmt.Errorf("%w: dealing from holder %d does not share its share value", ErrInvalidDealing, dealing.dealer)
+			}
+		}
+		dealers[i] = dealing.dealer
+	}
+
+	received, err := pvss.receiveSubShares(recipient, dealings, subShares)
+	if err != nil {
+		return Share{}, err
+	}
+
+	sizes := received[0].data.sizes
+	for _, sub := range received {
+		if !equalSizes(sub.data.sizes, sizes) || len(sizes) != meta.chunkCount {
+			return Share{}, fmt.Errorf("%w: sub-share from holder %d disagrees on chunk lengths", ErrInvalidDealing, sub.dealer)
+		}
+	}
+
+	coefficients, err := pvss.lagrangeCoefficients(dealers)
+	if err != nil {
+		return Share{}, fmt.Errorf("%w: %v", ErrInvalidDealing, err)
+	}
+
+	combined := shareData{
+		id:          recipient,
+		sizes:       sizes,
+		values:      make([]*Scalar, meta.chunkCount),
+		wholeScalar: meta.wholeScalar,
+	}
+	if meta.scheme == SchemePedersen {
+		combined.blindings = make([]*Scalar, meta.chunkCount)
+	}
+
+	newMeta := metadata{
+		scheme:      meta.scheme,
+		threshold:   threshold,
+		chunkCount:  meta.chunkCount,
+		commitments: make([][]Element, meta.chunkCount),
+		wholeScalar: meta.wholeScalar,
+	}
+
+	values := make([]*Scalar, len(received))
+	for chunkIdx := 0; chunkIdx < meta.chunkCount; chunkIdx++ {
+		for i, sub := range received {
+			values[i] = sub.data.values[chunkIdx]
+		}
+		combined.values[chunkIdx] = pvss.linearCombination(values, coefficients)
+
+		if combined.blindings != nil {
+			for i, sub := range received {
+				values[i] = sub.data.blindings[chunkIdx]
+			}
+			combined.blindings[chunkIdx] = pvss.linearCombination(values, coefficients)
+		}
+
+		commitments := make([]Element, threshold)
+		for k := range commitments {
+			commitments[k] = pvss.group.Identity()
+			for i, dealing := range dealings {
+				commitments[k] = commitments[k].Add(dealing.meta.commitments[chunkIdx][k].ScalarMult(coefficients[i]))
+			}
+		}
+		newMeta.commitments[chunkIdx] = commitments
+	}
+
+	newMeta.set = derivedShareSetID(meta.set, pvss.serializeCommitments(newMeta), latestDealing(dealings))
+	combined.set = newMeta.set
+
+	return pvss.encodeShare(combined, newMeta)
+}
+
+// VerifySameSecret reports whether two KeyChecks describe splits of the
+// same secret, as a resharing or refresh leaves them: both keep the
+// commitment to every chunk's secret unchanged.
+func (pvss *PedersenVSS) VerifySameSecret(oldKeyCheck, newKeyCheck string) (bool, error) {
+	oldMeta, err := pvss.decodeMetadata(oldKeyCheck)
+	if err != nil {
+		return false, err
+	}
+
+	newMeta, err := pvss.decodeMetadata(newKeyCheck)
+	if err != nil {
+		return false, err
+	}
+
+	if oldMeta.scheme != newMeta.scheme || oldMeta.chunkCount != newMeta.chunkCount {
+		return false, nil
+	}
+
+	for chunkIdx := range oldMeta.commitments {
+		if !oldMeta.commitments[chunkIdx][0].Equal(newMeta.commitments[chunkIdx][0]) {
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
@@ -0,0 +1,223 @@
+package pvss
+
+import (
+	"errors"
+	"testing"
+)
+
+// reshareAll moves shares to a committee of holders 1..n with the new
+// threshold, with the holders of shares[:dealers] dealing and every message
+// passed through its encoding
+func reshareAll(t *testing.T, pvss *PedersenVSS, shares []Share, dealers, threshold, n int) []Share {
+	t.Helper()
+
+	recipients := make([]int, n)
+	for i := range recipients {
+		recipients[i] = i + 1
+	}
+
+	var dealings []*Dealing
+	inbox := make(map[int][]*SubShare)
+
+	for _, share := range shares[:dealers] {
+		dealing, subShares, err := pvss.DealReshare(share, threshold, recipients)
+		if err != nil {
+			t.Fatalf("DealReshare failed: %v", err)
+		}
+
+		parsed, err := pvss.ParseDealing(dealing.Bytes())
+		if err != nil {
+			t.Fatalf("ParseDealing failed: %v", err)
+		}
+		dealings = append(dealings, parsed)
+
+		for id, sub := range subShares {
+			parsed, err := pvss.ParseSubShare(sub.Bytes())
+			if err != nil {
+				t.Fatalf("ParseSubShare failed: %v", err)
+			}
+			inbox[id] = append(inbox[id], parsed)
+		}
+	}
+
+	reshared := make([]Share, n)
+	for i := range reshared {
+		var err error
+		reshared[i], err = pvss.CombineReshare(shares[0].KeyCheck, i+1, dealings, inbox[i+1])
+		if err != nil {
+			t.Fatalf("CombineReshare for share %d failed: %v", i+1, err)
+		}
+	}
+
+	return reshared
+}
+
+// TestReshare_NewCommittee tests moving a 3-of-5 split to a 4-of-7 committee
+func TestReshare_NewCommittee(t *testing.T) {
+	secret := "from the old board to the new one"
+
+	for _, group := range []Group{P256(), Ristretto255()} {
+		for _, scheme := range []CommitmentScheme{SchemePedersen, SchemeFeldman} {
+			t.Run(group.Name()+"/"+scheme.String(), func(t *testing.T) {
+				pvss := NewPedersenVSS(WithGroup(group))
+
+				shares, err := pvss.SplitSecret(secret, 5, 3, WithCommitmentScheme(scheme))
+				if err != nil {
+					t.Fatalf("SplitSecret failed: %v", err)
+				}
+
+				reshared := reshareAll(t, pvss, shares[1:], 3, 4, 7)
+
+				for i, share := range reshared {
+					if share.KeyCheck != reshared[0].KeyCheck {
+						t.Errorf("share %d: holders derived different KeyChecks", i+1)
+					}
+					valid, err := pvss.VerifyShare(share)
+					if err != nil || !valid {
+						t.Errorf("share %d: reshared share failed verification: %v", i+1, err)
+					}
+				}
+
+				same, err := pvss.VerifySameSecret(shares[0].KeyCheck, reshared[0].KeyCheck)
+				if err != nil || !same {
+					t.Errorf("VerifySameSecret: got %v, %v", same, err)
+				}
+
+				reconstructed, err := pvss.ReconstructSecret(reshared[3:])
+				if err != nil {
+					t.Fatalf("ReconstructSecret failed: %v", err)
+				}
+				if reconstructed != secret {
+					t.Errorf("expected %q, got %q", secret, reconstructed)
+				}
+
+				if _, err := pvss.ReconstructSecret(reshared[:3]); err == nil {
+					t.Error("expected error for three shares under the new threshold")
+				}
+
+				// The new committee can shrink back down
+				again := reshareAll(t, pvss, reshared, 5, 2, 3)
+				reconstructed, err = pvss.ReconstructSecret(again[1:])
+				if err != nil || reconstructed != secret {
+					t.Errorf("second reshare: got %q, %v", reconstructed, err)
+				}
+			})
+		}
+	}
+}
+
+// TestVerifySameSecret tests that unrelated splits and refreshed splits are
+// told apart
+func TestVerifySameSecret(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	shares, err := pvss.SplitSecret("same secret", 3, 2)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+	other, err := pvss.SplitSecret("same secret", 3, 2)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+
+	if same, err := pvss.VerifySameSecret(shares[0].KeyCheck, other[0].KeyCheck); err != nil || same {
+		t.Errorf("independent split: got %v, %v", same, err)
+	}
+
+	refreshed := refreshAll(t, pvss, shares, 2)
+	if same, err := pvss.VerifySameSecret(shares[0].KeyCheck, refreshed[0].KeyCheck); err != nil || !same {
+		t.Errorf("refreshed split: got %v, %v", same, err)
+	}
+
+	if _, err := pvss.VerifySameSecret(shares[0].KeyCheck, "not a key check"); err == nil {
+		t.Error("expected error for malformed KeyCheck")
+	}
+}
+
+// TestReshare_Rejects tests that bad dealings are refused
+func TestReshare_Rejects(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	shares, err := pvss.SplitSecret("reshare rejects", 4, 2)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+	keyCheck := shares[0].KeyCheck
+	recipients := []int{1, 2, 3}
+
+	deal := func(share Share) (*Dealing, map[int]*SubShare) {
+		dealing, subShares, err := pvss.DealReshare(share, 3, recipients)
+		if err != nil {
+			t.Fatalf("DealReshare failed: %v", err)
+		}
+		return dealing, subShares
+	}
+	d1, s1 := deal(shares[0])
+	d2, s2 := deal(shares[1])
+
+	t.Run("valid", func(t *testing.T) {
+		if _, err := pvss.CombineReshare(keyCheck, 1, []*Dealing{d1, d2}, []*SubShare{s1[1], s2[1]}); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("too few dealings", func(t *testing.T) {
+		if _, err := pvss.CombineReshare(keyCheck, 1, []*Dealing{d1}, []*SubShare{s1[1]}); err == nil {
+			t.Error("expected error for fewer dealings than the old threshold")
+		}
+	})
+
+	t.Run("refresh dealing", func(t *testing.T) {
+		// A dealing of zero does not carry the dealer's share value
+		zero, subShares, err := pvss.DealRefresh(shares[1], []int{1, 2, 3, 4})
+		if err != nil {
+			t.Fatalf("DealRefresh failed: %v", err)
+		}
+		_, err = pvss.CombineReshare(keyCheck, 1, []*Dealing{d1, zero}, []*SubShare{s1[1], subShares[1]})
+		if !errors.Is(err, ErrInvalidDealing) {
+			t.Errorf("expected ErrInvalidDealing, got %v", err)
+		}
+	})
+
+	t.Run("mixed thresholds", func(t *testing.T) {
+		d3, s3, err := pvss.DealReshare(shares[2], 2, recipients)
+		if err != nil {
+			t.Fatalf("DealReshare failed: %v", err)
+		}
+		_, err = pvss.CombineReshare(keyCheck, 1, []*Dealing{d1, d3}, []*SubShare{s1[1], s3[1]})
+		if !errors.Is(err, ErrInvalidDealing) {
+			t.Errorf("expected ErrInvalidDealing, got %v", err)
+		}
+	})
+
+	t.Run("tampered sub-share", func(t *testing.T) {
+		tampered := *s2[1]
+		tampered.data.values = append([]*Scalar{}, s2[1].data.values...)
+		tampered.data.values[0] = NewScalar(P256()).Add(tampered.data.values[0], testScalar(1))
+
+		_, err := pvss.CombineReshare(keyCheck, 1, []*Dealing{d1, d2}, []*SubShare{s1[1], &tampered})
+		if !errors.Is(err, ErrShareMismatch) {
+			t.Errorf("expected ErrShareMismatch, got %v", err)
+		}
+	})
+
+	t.Run("other split", func(t *testing.T) {
+		other, err := pvss.SplitSecret("reshare rejects", 4, 2)
+		if err != nil {
+			t.Fatalf("SplitSecret failed: %v", err)
+		}
+		_, err = pvss.CombineReshare(other[0].KeyCheck, 1, []*Dealing{d1, d2}, []*SubShare{s1[1], s2[1]})
+		if !errors.Is(err, ErrInvalidDealing) {
+			t.Errorf("expected ErrInvalidDealing, got %v", err)
+		}
+	})
+
+	t.Run("bad threshold", func(t *testing.T) {
+		if _, _, err := pvss.DealReshare(shares[0], 0, recipients); err == nil {
+			t.Error("expected error for threshold 0")
+		}
+		if _, _, err := pvss.DealReshare(shares[0], 4, recipients); err == nil {
+			t.Error("expected error for threshold above the committee size")
+		}
+	})
+}
@@ -0,0 +1,366 @@
+package pvss
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrShareCorrupted marks a share whose values disagree with the polynomial
+// recovered by error-correcting reconstruction.
+var ErrShareCorrupted = errors.New("share value disagrees with the decoded polynomial")
+
+// ReconstructSecretRobust is ReconstructBytesRobust for string secrets.
+func (pvss *PedersenVSS) ReconstructSecretRobust(shares []Share, threshold int) (string, *ReconstructionReport, error) {
+	secret, report, err := pvss.ReconstructBytesRobust(shares, threshold)
+	if err != nil {
+		return "", report, err
+	}
+
+	return string(secret), report, nil
+}
+
+// ReconstructBytesRobust recovers a secret from shares of which up to
+// (n−threshold)/2 may be corrupted, using Berlekamp–Welch decoding over the
+// group's scalar field. It does not read KeyCheck or rely on phrase checksums,
+// so it works for shares whose metadata is missing or untrusted; the caller
+// supplies the threshold. Shares that cannot be decoded at all are treated
+// as lost. The report names every share found to be corrupted.
+func (pvss *PedersenVSS) ReconstructBytesRobust(shares []Share, threshold int) ([]byte, *ReconstructionReport, error) {
+	if len(shares) == 0 {
+		return nil, nil, errors.New("no shares provided")
+	}
+	if threshold < 1 {
+		return nil, nil, errors.New("threshold must be at least 1")
+	}
+
+	report := &ReconstructionReport{Threshold: threshold}
+	reject := func(index, id int, reason error) {
+		report.Rejected = append(report.Rejected, RejectedShare{Index: index, ShareID: id, Reason: reason})
+	}
+
+	var decoded []shareData
+	var indices []int
+
+	for i, share := range shares {
+		data, err := pvss.decodeShareDataUnchecked(share.Key)
+		if err != nil {
+			reject(i, 0, err)
+			continue
+		}
+		if data.id < 1 {
+			reject(i, data.id, errors.New("invalid share ID"))
+			continue
+		}
+
+		decoded = append(decoded, data)
+		indices = append(indices, i)
+	}
+
+	// The share set and chunk layout are not protected by anything, so take
+	// the majority
+	set := majorityShareSet(decoded)
+	sizes := majoritySizes(decoded)
+	chunkCount := len(sizes)
+
+	var candidates []shareData
+	var candidateIndices []int
+	idCount := make(map[int]int)
+
+	for i, data := range decoded {
+		if data.set != set {
+			reject(indices[i], data.id, ErrMetadataMismatch)
+			continue
+		}
+		if !equalSizes(data.sizes, sizes) {
+			reject(indices[i], data.id, ErrChunkSizeMismatch)
+			continue
+		}
+		candidates = append(candidates, data)
+		candidateIndices = append(candidateIndices, indices[i])
+		idCount[data.id]++
+	}
+
+	// Two shares claiming the same ID give two values for one point; neither
+	// can be trusted, so both are dropped
+	var points []shareData
+	var pointIndices []int
+	for i, data := range candidates {
+		if idCount[data.id] > 1 {
+			reject(candidateIndices[i], data.id, ErrDuplicateShare)
+			continue
+		}
+		points = append(points, data)
+		pointIndices = append(pointIndices, candidateIndices[i])
+	}
+
+	if chunkCount == 0 || len(points) < threshold {
+		return nil, report, fmt.Errorf("insufficient shares: need %d, got %d", threshold, len(points))
+	}
+
+	xs := make([]int, len(points))
+	for i, data := range points {
+		xs[i] = data.id
+	}
+
+	corrupted := make([]bool, len(points))
+	result := make([]byte, 0, chunkCount*pvss.chunkSize())
+
+	for chunkIdx := 0; chunkIdx < chunkCount; chunkIdx++ {
+		ys := make([]*Scalar, len(points))
+		for i, data := range points {
+			ys[i] = data.values[chunkIdx]
+		}
+
+		coefficients, err := pvss.berlekampWelch(xs, ys, threshold)
+		if err != nil {
+			return nil, report, fmt.Errorf("failed to decode chunk %d: %v", chunkIdx, err)
+		}
+
+		for i := range points {
+			if !pvss.evaluatePolynomial(coefficients, xs[i]).Equal(ys[i]) {
+				corrupted[i] = true
+			}
+		}
+
+		chunk, err := pvss.secretToChunk(coefficients[0], sizes[chunkIdx])
+		if err != nil {
+			return nil, report, fmt.Errorf("failed to reconstruct chunk %d: %v", chunkIdx, err)
+		}
+		result = append(result, chunk...)
+	}
+
+	for i, data := range points {
+		if corrupted[i] {
+			reject(pointIndices[i], data.id, ErrShareCorrupted)
+			continue
+		}
+		report.Accepted = append(report.Accepted, data.id)
+	}
+
+	return result, report, nil
+}
+
+func majorityShareSet(shares []shareData) ShareSetID {
+	counts := make(map[ShareSetID]int)
+	var best ShareSetID
+
+	for _, data := range shares {
+		counts[data.set]++
+		if counts[data.set] > counts[best] {
+			best = data.set
+		}
+	}
+
+	return best
+}
+
+// decodeShareDataUnchecked decodes a Key phrase without requiring its
+// checksum to match, for shares whose words may have rotted. A phrase that
+// fails its checksum is tried with the checksum length of each version.
+func (pvss *PedersenVSS) decodeShareDataUnchecked(key string) (shareData, error) {
+	words := strings.Fields(key)
+	if len(words) < 2 {
+		return shareData{}, errors.New("share phrase too short")
+	}
+
+	encoder := pvss.encoderFor(key)
+
+	var phrases []string
+	if phrase, valid := encoder.VerifyChecksum(key); valid {
+		phrases = append(phrases, phrase)
+	} else {
+		for _, count := range []int{encoder.checksumWordCount(), 1} {
+			if len(words) > count {
+				phrases = append(phrases, strings.Join(words[:len(words)-count], " "))
+			}
+		}
+	}
+
+	var err error
+	for _, phrase := range phrases {
+		var shareDataBytes []byte
+		shareDataBytes, err = pvss.decodePayload(encoder, phrase, shareMagic)
+		if err != nil {
+			err = fmt.Errorf("failed to decode share phrase: %v", err)
+			continue
+		}
+
+		var share shareData
+		share, err = pvss.deserializeShareData(shareDataBytes)
+		if err != nil {
+			err = fmt.Errorf("failed to parse share data: %v", err)
+			continue
+		}
+
+		return share, nil
+	}
+
+	return shareData{}, err
+}
+
+// berlekampWelch finds the polynomial of degree below threshold that agrees
+// with all but at most (n−threshold)/2 of the points (xs[i], ys[i]). It
+// solves Q(x_i) = y_i·E(x_i) for a monic error locator E of degree e and Q of
+// degree below e+threshold, then returns Q/E. The arithmetic is constant
+// time, but the elimination branches on which entries are zero.
+func (pvss *PedersenVSS) berlekampWelch(xs []int, ys []*Scalar, threshold int) ([]*Scalar, error) {
+	n := len(xs)
+	if n < threshold {
+		return nil, fmt.Errorf("need at least %d points, got %d", threshold, n)
+	}
+
+	e := (n - threshold) / 2
+	qLen := e + threshold
+	unknowns := qLen + e
+
+	// Row i: Σ q_j·x^j − y·Σ_{j<e} e_j·x^j = y·x^e
+	matrix := make([][]*Scalar, n)
+	for i := range xs {
+		x := pvss.field.newScalar().SetUint64(uint64(xs[i]))
+		y := ys[i]
+
+		row := make([]*Scalar, unknowns+1)
+		power := pvss.field.newScalar().SetUint64(1)
+		for j := 0; j < qLen; j++ {
+			row[j] = pvss.field.newScalar().Set(power)
+			if j < e {
+				term := pvss.field.newScalar().Multiply(y, power)
+				row[qLen+j] = term.Negate(term)
+			}
+			if j == e {
+				row[unknowns] = pvss.field.newScalar().Multiply(y, power)
+			}
+			power.Multiply(power, x)
+		}
+		matrix[i] = row
+	}
+
+	solution, err := pvss.solveLinearSystem(matrix, unknowns)
+	if err != nil {
+		return nil, errors.New("too many corrupted shares")
+	}
+
+	q := solution[:qLen]
+	errorLocator := make([]*Scalar, e+1)
+	copy(errorLocator, solution[qLen:])
+	errorLocator[e] = pvss.field.newScalar().SetUint64(1)
+
+	quotient, remainder := pvss.dividePolynomials(q, errorLocator)
+	for _, coeff := range remainder {
+		if !coeff.IsZero() {
+			return nil, errors.New("too many corrupted shares")
+		}
+	}
+
+	coefficients := make([]*Scalar, threshold)
+	for i := range coefficients {
+		if i < len(quotient) {
+			coefficients[i] = quotient[i]
+		} else {
+			coefficients[i] = pvss.field.newScalar()
+		}
+	}
+	for _, coeff := range quotient[min(threshold, len(quotient)):] {
+		if !coeff.IsZero() {
+			return nil, errors.New("too many corrupted shares")
+		}
+	}
+
+	// The decoded polynomial must agree with all but e points
+	disagreements := 0
+	for i := range xs {
+		if !pvss.evaluatePolynomial(coefficients, xs[i]).Equal(ys[i]) {
+			disagreements++
+		}
+	}
+	if disagreements > e {
+		return nil, errors.New("too many corrupted shares")
+	}
+
+	return coefficients, nil
+}
+
+// solveLinearSystem solves the augmented matrix modulo the group order by
+// Gaussian elimination. Free variables are set to zero; an inconsistent
+// system returns an error.
+func (pvss *PedersenVSS) solveLinearSystem(matrix [][]*Scalar, unknowns int) ([]*Scalar, error) {
+	pivotCols := make([]int, 0, unknowns)
+	row := 0
+
+	for col := 0; col < unknowns && row < len(matrix); col++ {
+		pivot := -1
+		for r := row; r < len(matrix); r++ {
+			if !matrix[r][col].IsZero() {
+				pivot = r
+				break
+			}
+		}
+		if pivot < 0 {
+			continue
+		}
+		matrix[row], matrix[pivot] = matrix[pivot], matrix[row]
+
+		inverse := pvss.field.newScalar().Invert(matrix[row][col])
+		for c := col; c <= unknowns; c++ {
+			matrix[row][c].Multiply(matrix[row][c], inverse)
+		}
+
+		term := pvss.field.newScalar()
+		for r := range matrix {
+			if r == row || matrix[r][col].IsZero() {
+				continue
+			}
+			factor := pvss.field.newScalar().Set(matrix[r][col])
+			for c := col; c <= unknowns; c++ {
+				matrix[r][c].Subtract(matrix[r][c], term.Multiply(factor, matrix[row][c]))
+			}
+		}
+
+		pivotCols = append(pivotCols, col)
+		row++
+	}
+
+	// Any remaining row reads 0 = b
+	for r := row; r < len(matrix); r++ {
+		if !matrix[r][unknowns].IsZero() {
+			return nil, errors.New("inconsistent linear system")
+		}
+	}
+
+	solution := make([]*Scalar, unknowns)
+	for i := range solution {
+		solution[i] = pvss.field.newScalar()
+	}
+	for r, col := range pivotCols {
+		solution[col] = pvss.field.newScalar().Set(matrix[r][unknowns])
+	}
+
+	return solution, nil
+}
+
+// dividePolynomials divides numerator by a monic denominator, both given as
+// coefficients in ascending order.
+func (pvss *PedersenVSS) dividePolynomials(numerator, denominator []*Scalar) ([]*Scalar, []*Scalar) {
+	remainder := make([]*Scalar, len(numerator))
+	for i, coeff := range numerator {
+		remainder[i] = pvss.field.newScalar().Set(coeff)
+	}
+
+	degree := len(denominator) - 1
+	if len(numerator) <= degree {
+		return []*Scalar{pvss.field.newScalar()}, remainder
+	}
+
+	quotient := make([]*Scalar, len(numerator)-degree)
+	term := pvss.field.newScalar()
+	for i := len(quotient) - 1; i >= 0; i-- {
+		coeff := pvss.field.newScalar().Set(remainder[i+degree])
+		quotient[i] = coeff
+		for j, d := range denominator {
+			remainder[i+j].Subtract(remainder[i+j], term.Multiply(coeff, d))
+		}
+	}
+
+	return quotient, remainder[:degree]
+}
@@ -0,0 +1,140 @@
+package pvss
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+)
+
+// rotWord replaces one word of the share phrase, as a faded or misread paper
+// backup would, without fixing up the checksum
+func rotWord(share Share, position int) Share {
+	words := strings.Fields(share.Key)
+	if words[position] == "zoo" {
+		words[position] = "abandon"
+	} else {
+		words[position] = "zoo"
+	}
+	return Share{Key: strings.Join(words, " ")}
+}
+
+// TestReconstructRobust_CorrectsErrors tests recovery with corrupted shares and no metadata
+func TestReconstructRobust_CorrectsErrors(t *testing.T) {
+	pvss := NewPedersenVSS()
+	secret := []byte("cold storage recovery without any metadata at all")
+
+	shares, err := pvss.SplitBytes(secret, 7, 3)
+	if err != nil {
+		t.Fatalf("SplitBytes failed: %v", err)
+	}
+
+	// Drop every KeyCheck and corrupt two shares
+	input := make([]Share, len(shares))
+	for i, share := range shares {
+		input[i] = Share{Key: share.Key}
+	}
+	input[2] = tamperShare(t, pvss, shares[2])
+	input[2].KeyCheck = ""
+	input[5] = rotWord(shares[5], 20)
+
+	reconstructed, report, err := pvss.ReconstructBytesRobust(input, 3)
+	if err != nil {
+		t.Fatalf("ReconstructBytesRobust failed: %v", err)
+	}
+
+	if !bytes.Equal(reconstructed, secret) {
+		t.Errorf("expected %q, got %q", secret, reconstructed)
+	}
+
+	if len(report.Rejected) != 2 {
+		t.Fatalf("expected 2 rejected shares, got %+v", report.Rejected)
+	}
+	for i, rejected := range report.Rejected {
+		expectedIndex := []int{2, 5}[i]
+		if rejected.Index != expectedIndex || !errors.Is(rejected.Reason, ErrShareCorrupted) {
+			t.Errorf("unexpected rejection: %+v", rejected)
+		}
+	}
+
+	if len(report.Accepted) != 5 {
+		t.Errorf("expected 5 accepted shares, got %v", report.Accepted)
+	}
+}
+
+// TestReconstructRobust_NoErrors tests that honest shares decode with nothing rejected
+func TestReconstructRobust_NoErrors(t *testing.T) {
+	pvss := NewPedersenVSS()
+	secret := "all shares honest"
+
+	shares, err := pvss.SplitSecret(secret, 5, 3)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+
+	for _, subset := range [][]Share{shares, shares[:3], shares[1:]} {
+		reconstructed, report, err := pvss.ReconstructSecretRobust(subset, 3)
+		if err != nil {
+			t.Fatalf("ReconstructSecretRobust failed: %v", err)
+		}
+		if reconstructed != secret {
+			t.Errorf("expected %q, got %q", secret, reconstructed)
+		}
+		if len(report.Rejected) != 0 {
+			t.Errorf("unexpected rejections: %+v", report.Rejected)
+		}
+	}
+}
+
+// TestReconstructRobust_TooManyErrors tests failure when corruption exceeds the decoding radius
+func TestReconstructRobust_TooManyErrors(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	shares, err := pvss.SplitSecret("too much rot", 5, 3)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+
+	// Five shares at threshold three correct a single error
+	shares[0] = tamperShare(t, pvss, shares[0])
+	shares[3] = tamperShare(t, pvss, shares[3])
+
+	if reconstructed, _, err := pvss.ReconstructSecretRobust(shares, 3); err == nil && reconstructed == "too much rot" {
+		t.Error("expected decoding to fail beyond the correction radius")
+	}
+
+	if _, _, err := pvss.ReconstructSecretRobust(shares[:2], 3); err == nil {
+		t.Error("expected error for fewer shares than threshold")
+	}
+
+	if _, _, err := pvss.ReconstructSecretRobust(nil, 3); err == nil {
+		t.Error("expected error for empty shares")
+	}
+}
+
+// TestBerlekampWelch tests the decoder directly on a known polynomial
+func TestBerlekampWelch(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	coefficients := []*Scalar{testScalar(1234), testScalar(56), testScalar(7)}
+	xs := []int{1, 2, 3, 4, 5, 6, 7, 8}
+	ys := make([]*Scalar, len(xs))
+	for i, x := range xs {
+		ys[i] = pvss.evaluatePolynomial(coefficients, x)
+	}
+
+	// 8 points at threshold 3 correct two errors
+	ys[1] = testScalar(99)
+	ys[6].Add(ys[6], testScalar(1))
+
+	decoded, err := pvss.berlekampWelch(xs, ys, 3)
+	if err != nil {
+		t.Fatalf("berlekampWelch failed: %v", err)
+	}
+
+	for i := range coefficients {
+		if !decoded[i].Equal(coefficients[i]) {
+			t.Errorf("coefficient %d: expected %v, got %v", i, coefficients[i], decoded[i])
+		}
+	}
+}
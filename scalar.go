@@ -0,0 +1,337 @@
+package pvss
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+// maxScalarLimbs is the number of 64-bit limbs needed for the largest
+// supported order, the 521-bit order of P-521.
+const maxScalarLimbs = 9
+
+type limbs = [maxScalarLimbs]uint64
+
+// scalarField is the integers modulo a group order. Arithmetic runs on a
+// fixed number of limbs in Montgomery form, so its timing depends only on
+// the order and never on the values.
+type scalarField struct {
+	modulus  limbs
+	n        int    // Limbs in use
+	size     int    // Encoded length in bytes
+	topMask  byte   // Mask for the most significant encoded byte
+	n0inv    uint64 // −modulus⁻¹ mod 2⁶⁴
+	rSquared limbs  // R² mod modulus, with R = 2^(64·n)
+	invExp   []byte // modulus − 2, the public exponent for inversion
+}
+
+var scalarFields sync.Map // Order in hex → *scalarField
+
+// scalarFieldOf returns the scalar field of g, computing it on first use.
+func scalarFieldOf(g Group) *scalarField {
+	key := g.Order().Text(16)
+	if f, ok := scalarFields.Load(key); ok {
+		return f.(*scalarField)
+	}
+
+	f, _ := scalarFields.LoadOrStore(key, newScalarField(g.Order()))
+	return f.(*scalarField)
+}
+
+func newScalarField(order *big.Int) *scalarField {
+	if order.Bit(0) == 0 || order.BitLen() <= 64 || order.BitLen() > 64*maxScalarLimbs {
+		panic("pvss: unsupported group order")
+	}
+
+	f := &scalarField{
+		n:    (order.BitLen() + 63) / 64,
+		size: (order.BitLen() + 7) / 8,
+	}
+	f.topMask = 0xff
+	if excess := f.size*8 - order.BitLen(); excess > 0 {
+		f.topMask >>= excess
+	}
+	bigToLimbs(&f.modulus, order)
+
+	// Newton's iteration doubles the correct low bits of the inverse
+	inv := f.modulus[0]
+	for i := 0; i < 6; i++ {
+		inv *= 2 - f.modulus[0]*inv
+	}
+	f.n0inv = -inv
+
+	r := new(big.Int).Lsh(big.NewInt(1), uint(128*f.n))
+	bigToLimbs(&f.rSquared, r.Mod(r, order))
+
+	f.invExp = new(big.Int).Sub(order, big.NewInt(2)).Bytes()
+
+	return f
+}
+
+func bigToLimbs(dst *limbs, x *big.Int) {
+	buf := x.FillBytes(make([]byte, 8*maxScalarLimbs))
+	for i := range dst {
+		dst[i] = binary.BigEndian.Uint64(buf[len(buf)-8*(i+1):])
+	}
+}
+
+// Scalar is an integer modulo the order of a Group. The zero value is not
+// usable; create scalars with NewScalar. Operations run in time independent
+// of the values involved, and all operands must belong to the same group.
+type Scalar struct {
+	field *scalarField
+	l     limbs // Montgomery form
+}
+
+// NewScalar returns a new zero scalar of group g.
+func NewScalar(g Group) *Scalar {
+	return scalarFieldOf(g).newScalar()
+}
+
+// RandomScalar returns a uniformly random scalar of group g, such as a
+// private key or a proof witness.
+func RandomScalar(g Group) (*Scalar, error) {
+	return scalarFieldOf(g).random()
+}
+
+func (f *scalarField) newScalar() *Scalar {
+	return &Scalar{field: f}
+}
+
+// random returns a uniformly random scalar by rejection sampling.
+func (f *scalarField) random() (*Scalar, error) {
+	buf := make([]byte, f.size)
+	s := f.newScalar()
+
+	for {
+		if _, err := rand.Read(buf); err != nil {
+			return nil, fmt.Errorf("failed to generate random scalar: %v", err)
+		}
+		buf[0] &= f.topMask
+
+		if _, err := s.SetBytes(buf); err == nil {
+			return s, nil
+		}
+	}
+}
+
+// Set sets s = x and returns s.
+func (s *Scalar) Set(x *Scalar) *Scalar {
+	s.field, s.l = x.field, x.l
+	return s
+}
+
+// SetUint64 sets s = v and returns s. Every supported order exceeds 2⁶⁴,
+// so v needs no reduction.
+func (s *Scalar) SetUint64(v uint64) *Scalar {
+	var x limbs
+	x[0] = v
+	s.field.montMul(&s.l, &x, &s.field.rSquared)
+	return s
+}
+
+// SetBytes sets s to the big-endian encoding x, which must be exactly Size
+// bytes and below the order, and returns s.
+func (s *Scalar) SetBytes(x []byte) (*Scalar, error) {
+	f := s.field
+	if len(x) != f.size {
+		return nil, fmt.Errorf("invalid scalar length: expected %d bytes, got %d", f.size, len(x))
+	}
+
+	buf := make([]byte, 8*maxScalarLimbs)
+	copy(buf[len(buf)-f.size:], x)
+
+	var v limbs
+	for i := range v {
+		v[i] = binary.BigEndian.Uint64(buf[len(buf)-8*(i+1):])
+	}
+
+	// v < modulus exactly when subtracting it borrows
+	var borrow uint64
+	for i := 0; i < maxScalarLimbs; i++ {
+		_, borrow = bits.Sub64(v[i], f.modulus[i], borrow)
+	}
+	if borrow == 0 {
+		return nil, errors.New("scalar is not below the group order")
+	}
+
+	f.montMul(&s.l, &v, &f.rSquared)
+	return s, nil
+}
+
+// Bytes returns the big-endian encoding of s in Size bytes.
+func (s *Scalar) Bytes() []byte {
+	f := s.field
+
+	var one, v limbs
+	one[0] = 1
+	f.montMul(&v, &s.l, &one)
+
+	buf := make([]byte, 8*maxScalarLimbs)
+	for i := range v {
+		binary.BigEndian.PutUint64(buf[len(buf)-8*(i+1):], v[i])
+	}
+	return buf[len(buf)-f.size:]
+}
+
+// Size is the length of the encoding of s in bytes.
+func (s *Scalar) Size() int {
+	return s.field.size
+}
+
+// Add sets s = x + y and returns s.
+func (s *Scalar) Add(x, y *Scalar) *Scalar {
+	f := x.field
+
+	var z limbs
+	var carry uint64
+	for i := 0; i < f.n; i++ {
+		z[i], carry = bits.Add64(x.l[i], y.l[i], carry)
+	}
+	f.reduceOnce(&z, carry)
+
+	s.field, s.l = f, z
+	return s
+}
+
+// Subtract sets s = x − y and returns s.
+func (s *Scalar) Subtract(x, y *Scalar) *Scalar {
+	f := x.field
+
+	var z limbs
+	var borrow uint64
+	for i := 0; i < f.n; i++ {
+		z[i], borrow = bits.Sub64(x.l[i], y.l[i], borrow)
+	}
+
+	// Add the modulus back if the subtraction wrapped
+	mask := -borrow
+	var carry uint64
+	for i := 0; i < f.n; i++ {
+		z[i], carry = bits.Add64(z[i], f.modulus[i]&mask, carry)
+	}
+
+	s.field, s.l = f, z
+	return s
+}
+
+// Negate sets s = −x and returns s.
+func (s *Scalar) Negate(x *Scalar) *Scalar {
+	return s.Subtract(x.field.newScalar(), x)
+}
+
+// Multiply sets s = x·y and returns s.
+func (s *Scalar) Multiply(x, y *Scalar) *Scalar {
+	f := x.field
+
+	var z limbs
+	f.montMul(&z, &x.l, &y.l)
+
+	s.field, s.l = f, z
+	return s
+}
+
+// Invert sets s = x⁻¹ and returns s. The inverse of zero is zero.
+func (s *Scalar) Invert(x *Scalar) *Scalar {
+	f := x.field
+
+	// Fermat's little theorem: x⁻¹ = x^(order−2). The exponent is public,
+	// so square-and-multiply over its bits does not depend on x.
+	base := x.l
+	result := f.newScalar().SetUint64(1).l
+	for _, b := range f.invExp {
+		for bit := 7; bit >= 0; bit-- {
+			f.montMul(&result, &result, &result)
+			if b>>bit&1 == 1 {
+				f.montMul(&result, &result, &base)
+			}
+		}
+	}
+
+	s.field, s.l = f, result
+	return s
+}
+
+// Equal reports whether s and x are the same scalar.
+func (s *Scalar) Equal(x *Scalar) bool {
+	var diff uint64
+	for i := range s.l {
+		diff |= s.l[i] ^ x.l[i]
+	}
+	return s.field == x.field && (diff|-diff)>>63 == 0
+}
+
+// IsZero reports whether s is zero.
+func (s *Scalar) IsZero() bool {
+	return s.Equal(s.field.newScalar())
+}
+
+// String returns the scalar in hexadecimal, for debugging.
+func (s *Scalar) String() string {
+	return fmt.Sprintf("%x", s.Bytes())
+}
+
+// reduceOnce subtracts the modulus from the n-limb value z with the given
+// carry limb if the result stays non-negative, mapping [0, 2·modulus) into
+// [0, modulus).
+func (f *scalarField) reduceOnce(z *limbs, carry uint64) {
+	var d limbs
+	var borrow uint64
+	for i := 0; i < f.n; i++ {
+		d[i], borrow = bits.Sub64(z[i], f.modulus[i], borrow)
+	}
+	_, borrow = bits.Sub64(carry, 0, borrow)
+
+	// Keep z when the subtraction borrowed
+	mask := -borrow
+	for i := 0; i < f.n; i++ {
+		z[i] = z[i]&mask | d[i]&^mask
+	}
+}
+
+// montMul sets z = x·y·R⁻¹ mod modulus using coarsely integrated operand
+// scanning. z may alias x or y.
+func (f *scalarField) montMul(z, x, y *limbs) {
+	n := f.n
+	var t [maxScalarLimbs + 2]uint64
+
+	for i := 0; i < n; i++ {
+		// t += x·y[i]
+		var c, cc uint64
+		for j := 0; j < n; j++ {
+			hi, lo := bits.Mul64(x[j], y[i])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j], c = lo, hi
+		}
+		t[n], cc = bits.Add64(t[n], c, 0)
+		t[n+1] = cc
+
+		// t = (t + m·modulus) / 2⁶⁴, with m chosen to clear the low limb
+		m := t[0] * f.n0inv
+		hi, lo := bits.Mul64(m, f.modulus[0])
+		_, cc = bits.Add64(lo, t[0], 0)
+		c = hi + cc
+		for j := 1; j < n; j++ {
+			hi, lo = bits.Mul64(m, f.modulus[j])
+			lo, cc = bits.Add64(lo, t[j], 0)
+			hi += cc
+			lo, cc = bits.Add64(lo, c, 0)
+			hi += cc
+			t[j-1], c = lo, hi
+		}
+		t[n-1], cc = bits.Add64(t[n], c, 0)
+		t[n] = t[n+1] + cc
+	}
+
+	var result limbs
+	copy(result[:n], t[:n])
+	f.reduceOnce(&result, t[n])
+	*z = result
+}
@@ -0,0 +1,186 @@
+package pvss
+
+import (
+	"bytes"
+	"crypto/rand"
+	"math/big"
+	"testing"
+)
+
+// testScalar returns v as a P-256 scalar
+func testScalar(v uint64) *Scalar {
+	return NewScalar(P256()).SetUint64(v)
+}
+
+// bigScalar returns k, which must be below the order, as a scalar of g
+func bigScalar(t testing.TB, g Group, k *big.Int) *Scalar {
+	t.Helper()
+
+	s, err := NewScalar(g).SetBytes(k.FillBytes(make([]byte, (g.Order().BitLen()+7)/8)))
+	if err != nil {
+		t.Fatalf("SetBytes(%x) failed: %v", k, err)
+	}
+	return s
+}
+
+func scalarToBig(s *Scalar) *big.Int {
+	return new(big.Int).SetBytes(s.Bytes())
+}
+
+// TestScalar_MatchesBigInt tests the Montgomery arithmetic of every group's
+// scalar field against math/big
+func TestScalar_MatchesBigInt(t *testing.T) {
+	for _, group := range allGroups() {
+		t.Run(group.Name(), func(t *testing.T) {
+			order := group.Order()
+			values := []*big.Int{
+				big.NewInt(0),
+				big.NewInt(1),
+				big.NewInt(2),
+				new(big.Int).Sub(order, big.NewInt(1)),
+				new(big.Int).Rsh(order, 1),
+			}
+			for i := 0; i < 8; i++ {
+				k, err := rand.Int(rand.Reader, order)
+				if err != nil {
+					t.Fatal(err)
+				}
+				values = append(values, k)
+			}
+
+			for _, a := range values {
+				x := bigScalar(t, group, a)
+
+				if !bytes.Equal(x.Bytes(), a.FillBytes(make([]byte, x.Size()))) {
+					t.Fatalf("%x: encoding round trip failed", a)
+				}
+
+				negated := new(big.Int).Neg(a)
+				if got := scalarToBig(NewScalar(group).Negate(x)); got.Cmp(negated.Mod(negated, order)) != 0 {
+					t.Errorf("−%x: got %x", a, got)
+				}
+
+				inverse := new(big.Int).ModInverse(a, order)
+				if inverse == nil {
+					inverse = big.NewInt(0)
+				}
+				if got := scalarToBig(NewScalar(group).Invert(x)); got.Cmp(inverse) != 0 {
+					t.Errorf("%x⁻¹: expected %x, got %x", a, inverse, got)
+				}
+
+				for _, b := range values {
+					y := bigScalar(t, group, b)
+
+					sum := new(big.Int).Add(a, b)
+					if got := scalarToBig(NewScalar(group).Add(x, y)); got.Cmp(sum.Mod(sum, order)) != 0 {
+						t.Errorf("%x + %x: expected %x, got %x", a, b, sum, got)
+					}
+
+					difference := new(big.Int).Sub(a, b)
+					if got := scalarToBig(NewScalar(group).Subtract(x, y)); got.Cmp(difference.Mod(difference, order)) != 0 {
+						t.Errorf("%x − %x: expected %x, got %x", a, b, difference, got)
+					}
+
+					product := new(big.Int).Mul(a, b)
+					if got := scalarToBig(NewScalar(group).Multiply(x, y)); got.Cmp(product.Mod(product, order)) != 0 {
+						t.Errorf("%x · %x: expected %x, got %x", a, b, product, got)
+					}
+
+					if x.Equal(y) != (a.Cmp(b) == 0) {
+						t.Errorf("Equal(%x, %x) is wrong", a, b)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestScalar_SetBytes tests that non-canonical encodings are rejected
+func TestScalar_SetBytes(t *testing.T) {
+	for _, group := range allGroups() {
+		size := (group.Order().BitLen() + 7) / 8
+
+		if _, err := NewScalar(group).SetBytes(group.Order().FillBytes(make([]byte, size))); err == nil {
+			t.Errorf("%s: expected error for the order itself", group.Name())
+		}
+		if _, err := NewScalar(group).SetBytes(bytes.Repeat([]byte{0xff}, size)); err == nil {
+			t.Errorf("%s: expected error for a value above the order", group.Name())
+		}
+		if _, err := NewScalar(group).SetBytes(make([]byte, size+1)); err == nil {
+			t.Errorf("%s: expected error for wrong length", group.Name())
+		}
+	}
+}
+
+// TestScalar_Random tests that random scalars are in range and distinct
+func TestScalar_Random(t *testing.T) {
+	for _, group := range allGroups() {
+		field := scalarFieldOf(group)
+
+		a, err := field.random()
+		if err != nil {
+			t.Fatalf("%s: random failed: %v", group.Name(), err)
+		}
+		b, err := field.random()
+		if err != nil {
+			t.Fatalf("%s: random failed: %v", group.Name(), err)
+		}
+
+		if scalarToBig(a).Cmp(group.Order()) >= 0 || a.Equal(b) {
+			t.Errorf("%s: unexpected random scalars %v and %v", group.Name(), a, b)
+		}
+	}
+}
+
+// TestLagrangeCoefficients tests that the coefficients recover f(0) for
+// every group
+func TestLagrangeCoefficients(t *testing.T) {
+	for _, group := range allGroups() {
+		pvss := NewPedersenVSS(WithGroup(group))
+
+		secret, err := pvss.randomScalar()
+		if err != nil {
+			t.Fatal(err)
+		}
+		coefficients, err := pvss.generateRandomPolynomial(secret, 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		ids := []int{2, 5, 7, 255}
+		values := make([]*Scalar, len(ids))
+		for i, id := range ids {
+			values[i] = pvss.evaluatePolynomial(coefficients, id)
+		}
+
+		reconstructed, err := pvss.lagrangeInterpolation(values, ids)
+		if err != nil {
+			t.Fatalf("%s: lagrangeInterpolation failed: %v", group.Name(), err)
+		}
+		if !reconstructed.Equal(secret) {
+			t.Errorf("%s: expected %v, got %v", group.Name(), secret, reconstructed)
+		}
+
+		if _, err := pvss.lagrangeInterpolation(values[:2], []int{3, 3}); err == nil {
+			t.Errorf("%s: expected error for duplicate IDs", group.Name())
+		}
+	}
+}
+
+func BenchmarkScalarMultiply(b *testing.B) {
+	x := NewScalar(P256()).Negate(testScalar(3))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Multiply(x, x)
+	}
+}
+
+func BenchmarkScalarInvert(b *testing.B) {
+	x := NewScalar(P256()).Negate(testScalar(3))
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		x.Invert(x)
+	}
+}
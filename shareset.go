@@ -0,0 +1,124 @@
+package pvss
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// shareSetIDSize is the encoded size of a ShareSetID: an 8-byte fingerprint
+// followed by a 4-byte creation time in Unix seconds.
+const shareSetIDSize = 12
+
+// ShareSetID identifies the split that produced a share. Every share of a
+// split, and its KeyCheck, carries the same ID. Shares printed before share
+// sets existed have the zero ShareSetID.
+type ShareSetID struct {
+	Fingerprint [8]byte   // Hash of the commitments and a random nonce
+	CreatedAt   time.Time // When the split was made, to the second
+}
+
+// String returns the fingerprint in hexadecimal.
+func (id ShareSetID) String() string {
+	return hex.EncodeToString(id.Fingerprint[:])
+}
+
+// newShareSetID fingerprints a split from its serialized commitments and a
+// random nonce, so two splits with equal commitments still differ.
+func newShareSetID(commitments []byte, createdAt time.Time) (ShareSetID, error) {
+	nonce := make([]byte, 16)
+	if _, err := rand.Read(nonce); err != nil {
+		return ShareSetID{}, fmt.Errorf("failed to generate share set nonce: %v", err)
+	}
+
+	created := make([]byte, 4)
+	binary.BigEndian.PutUint32(created, uint32(createdAt.Unix()))
+
+	hash := sha256.New()
+	hash.Write([]byte("pvss/share-set"))
+	hash.Write(commitments)
+	hash.Write(nonce)
+	hash.Write(created)
+
+	var id ShareSetID
+	copy(id.Fingerprint[:], hash.Sum(nil))
+	id.CreatedAt = time.Unix(createdAt.Unix(), 0).UTC()
+
+	return id, nil
+}
+
+// derivedShareSetID derives the ID of a split produced from another by a
+// refresh or resharing, from the ID it replaces and its new commitments.
+// Every holder derives the same ID, so no nonce is used.
+func derivedShareSetID(previous ShareSetID, commitments []byte, createdAt time.Time) ShareSetID {
+	hash := sha256.New()
+	hash.Write([]byte("pvss/share-set/derived"))
+	hash.Write(appendShareSetID(nil, previous))
+	hash.Write(commitments)
+
+	var id ShareSetID
+	copy(id.Fingerprint[:], hash.Sum(nil))
+	id.CreatedAt = time.Unix(createdAt.Unix(), 0).UTC()
+
+	return id
+}
+
+// jointShareSetID derives the ID of a split generated without a dealer from
+// its commitments, which every participant sees alike, so no nonce is used.
+func jointShareSetID(commitments []byte, createdAt time.Time) ShareSetID {
+	hash := sha256.New()
+	hash.Write([]byte("pvss/share-set/joint"))
+	hash.Write(commitments)
+
+	var id ShareSetID
+	copy(id.Fingerprint[:], hash.Sum(nil))
+	id.CreatedAt = time.Unix(createdAt.Unix(), 0).UTC()
+
+	return id
+}
+
+func appendShareSetID(dst []byte, id ShareSetID) []byte {
+	dst = append(dst, id.Fingerprint[:]...)
+	return binary.BigEndian.AppendUint32(dst, uint32(id.CreatedAt.Unix()))
+}
+
+func readShareSetID(data []byte, offset int) (ShareSetID, int, error) {
+	if offset+shareSetIDSize > len(data) {
+		return ShareSetID{}, 0, errors.New("insufficient share set data")
+	}
+
+	var id ShareSetID
+	copy(id.Fingerprint[:], data[offset:offset+8])
+	id.CreatedAt = time.Unix(int64(binary.BigEndian.Uint32(data[offset+8:offset+12])), 0).UTC()
+
+	return id, offset + shareSetIDSize, nil
+}
+
+// ShareSetID returns the identifier of the split that produced share, read
+// from its Key. When the share also carries a KeyCheck, both must name the
+// same share set.
+func (pvss *PedersenVSS) ShareSetID(share Share) (ShareSetID, error) {
+	data, err := pvss.decodeShareData(share.Key)
+	if err != nil {
+		return ShareSetID{}, err
+	}
+
+	if share.KeyCheck == "" {
+		return data.set, nil
+	}
+
+	meta, err := pvss.decodeMetadata(share.KeyCheck)
+	if err != nil {
+		return ShareSetID{}, err
+	}
+
+	if meta.set != data.set {
+		return ShareSetID{}, fmt.Errorf("%w: Key belongs to share set %s, KeyCheck to %s", ErrMetadataMismatch, data.set, meta.set)
+	}
+
+	return data.set, nil
+}
@@ -0,0 +1,88 @@
+package pvss
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+// TestShareSetID tests that every share of a split reports the same identifier
+func TestShareSetID(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	before := time.Now().Add(-time.Second)
+	shares, err := pvss.SplitSecret("backup X", 5, 3)
+	if err != nil {
+		t.Fatalf("SplitSecret failed: %v", err)
+	}
+	after := time.Now().Add(time.Second)
+
+	reference, err := pvss.ShareSetID(shares[0])
+	if err != nil {
+		t.Fatalf("ShareSetID failed: %v", err)
+	}
+
+	if reference.CreatedAt.Before(before) || reference.CreatedAt.After(after) {
+		t.Errorf("creation time %v outside [%v, %v]", reference.CreatedAt, before, after)
+	}
+
+	if len(reference.String()) != 16 {
+		t.Errorf("expected 16 hex characters, got %q", reference.String())
+	}
+
+	for i, share := range shares {
+		id, err := pvss.ShareSetID(share)
+		if err != nil {
+			t.Fatalf("ShareSetID failed for share %d: %v", i, err)
+		}
+		if id != reference {
+			t.Errorf("share %d reports share set %s, expected %s", i, id, reference)
+		}
+
+		// The Key alone is enough to identify the share set
+		keyOnly, err := pvss.ShareSetID(Share{Key: share.Key})
+		if err != nil || keyOnly != reference {
+			t.Errorf("share %d Key reports share set %s (err %v), expected %s", i, keyOnly, err, reference)
+		}
+	}
+}
+
+// TestShareSetID_Distinct tests that identical secrets split twice get different identifiers
+func TestShareSetID_Distinct(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	first, _ := pvss.SplitSecret("same secret", 3, 2)
+	second, _ := pvss.SplitSecret("same secret", 3, 2)
+
+	firstID, _ := pvss.ShareSetID(first[0])
+	secondID, _ := pvss.ShareSetID(second[0])
+
+	if firstID.Fingerprint == secondID.Fingerprint {
+		t.Error("expected different fingerprints for separate splits")
+	}
+}
+
+// TestShareSetID_Mismatch tests a Key paired with the KeyCheck of another split
+func TestShareSetID_Mismatch(t *testing.T) {
+	pvss := NewPedersenVSS()
+
+	first, _ := pvss.SplitSecret("first", 3, 2)
+	second, _ := pvss.SplitSecret("other", 3, 2)
+
+	mixed := Share{Key: first[0].Key, KeyCheck: second[0].KeyCheck}
+
+	if _, err := pvss.ShareSetID(mixed); !errors.Is(err, ErrMetadataMismatch) {
+		t.Errorf("expected ErrMetadataMismatch, got %v", err)
+	}
+
+	if _, err := pvss.VerifyShare(mixed); !errors.Is(err, ErrMetadataMismatch) {
+		t.Errorf("expected VerifyShare to fail with ErrMetadataMismatch, got %v", err)
+	}
+
+	// Reconstruction names the share whose Key belongs elsewhere
+	_, err := pvss.ReconstructSecret([]Share{second[1], mixed, second[2]})
+	var mismatch *MetadataMismatchError
+	if !errors.As(err, &mismatch) || len(mismatch.Indices) != 1 || mismatch.Indices[0] != 1 {
+		t.Errorf("expected mismatch naming share 1, got %v", err)
+	}
+}
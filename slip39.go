@@ -0,0 +1,587 @@
+package pvss
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// SLIP-0039 parameters, see
+// https://github.com/satoshilabs/slips/blob/master/slip-0039.md
+const (
+	slip39RadixBits         = 10
+	slip39IDBits            = 15
+	slip39IterationExpBits  = 4
+	slip39ChecksumWords     = 3
+	slip39MetadataWords     = 4 // Identifier, flags and group parameters
+	slip39MinStrengthBytes  = 16
+	slip39MaxShareCount     = 16
+	slip39DigestLength      = 4
+	slip39DigestIndex       = 254
+	slip39SecretIndex       = 255
+	slip39BaseIterations    = 10000
+	slip39RoundCount        = 4
+	slip39Customization     = "shamir"
+	slip39CustomizationExt  = "shamir_extendable"
+	slip39MinMnemonicLength = slip39MetadataWords + slip39ChecksumWords + (slip39MinStrengthBytes*8+slip39RadixBits-1)/slip39RadixBits
+)
+
+// SLIP39Group describes one group of a SLIP-0039 sharing: MemberThreshold of
+// its MemberCount member shares recover the group share.
+type SLIP39Group struct {
+	MemberThreshold int
+	MemberCount     int
+}
+
+// SLIP39Share is a single decoded SLIP-0039 share.
+type SLIP39Share struct {
+	Identifier        uint16 // Random 15-bit identifier shared by all shares of a secret
+	Extendable        bool   // Whether the identifier is excluded from the encryption salt
+	IterationExponent int    // PBKDF2 iteration count is 10000 << IterationExponent
+	GroupIndex        int
+	GroupThreshold    int
+	GroupCount        int
+	MemberIndex       int
+	MemberThreshold   int
+	Value             []byte // Share value, the same length as the master secret
+}
+
+// SLIP39Encoder reads and writes SLIP-0039 mnemonics, the share format spoken
+// by hardware wallets. Unlike MnemonicEncoder it carries its own Shamir
+// sharing over GF(256), so shares can be exchanged with other SLIP-0039
+// implementations.
+type SLIP39Encoder struct {
+	wordList []string
+	wordMap  map[string]int
+}
+
+func NewSLIP39Encoder() *SLIP39Encoder {
+	wordList := SLIP39Words()
+	wordMap := make(map[string]int)
+	for i, word := range wordList {
+		wordMap[word] = i
+	}
+
+	return &SLIP39Encoder{
+		wordList: wordList,
+		wordMap:  wordMap,
+	}
+}
+
+// GenerateMnemonics splits masterSecret into groups of SLIP-0039 mnemonics.
+// Any groupThreshold groups, each with its member threshold of mnemonics,
+// recover the secret. The secret is encrypted with passphrase first; an empty
+// passphrase is allowed. The result holds one slice of mnemonics per group.
+func (se *SLIP39Encoder) GenerateMnemonics(groupThreshold int, groups []SLIP39Group, masterSecret, passphrase []byte, iterationExponent int, extendable bool) ([][]string, error) {
+	if len(masterSecret) < slip39MinStrengthBytes || len(masterSecret)%2 != 0 {
+		return nil, fmt.Errorf("master secret must be an even number of bytes, at least %d", slip39MinStrengthBytes)
+	}
+	if iterationExponent < 0 || iterationExponent >= 1<<slip39IterationExpBits {
+		return nil, fmt.Errorf("iteration exponent must be between 0 and %d", 1<<slip39IterationExpBits-1)
+	}
+	if groupThreshold < 1 || groupThreshold > len(groups) {
+		return nil, errors.New("group threshold must be between 1 and the number of groups")
+	}
+	if len(groups) > slip39MaxShareCount {
+		return nil, fmt.Errorf("number of groups cannot exceed %d", slip39MaxShareCount)
+	}
+	for i, group := range groups {
+		if group.MemberThreshold < 1 || group.MemberThreshold > group.MemberCount || group.MemberCount > slip39MaxShareCount {
+			return nil, fmt.Errorf("invalid member threshold or count for group %d", i)
+		}
+		if group.MemberThreshold == 1 && group.MemberCount > 1 {
+			return nil, fmt.Errorf("group %d: a member threshold of 1 requires a single member share", i)
+		}
+	}
+	for _, b := range passphrase {
+		if b < 32 || b > 126 {
+			return nil, errors.New("passphrase must contain only printable ASCII characters")
+		}
+	}
+
+	idBytes := make([]byte, 2)
+	if _, err := rand.Read(idBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate identifier: %v", err)
+	}
+	identifier := binary.BigEndian.Uint16(idBytes) & (1<<slip39IDBits - 1)
+
+	encrypted := slip39Encrypt(masterSecret, passphrase, iterationExponent, identifier, extendable)
+
+	groupShares, err := slip39SplitSecret(groupThreshold, len(groups), encrypted)
+	if err != nil {
+		return nil, err
+	}
+
+	mnemonics := make([][]string, len(groups))
+	for groupIdx, group := range groups {
+		memberShares, err := slip39SplitSecret(group.MemberThreshold, group.MemberCount, groupShares[groupIdx])
+		if err != nil {
+			return nil, err
+		}
+
+		for memberIdx, value := range memberShares {
+			share := SLIP39Share{
+				Identifier:        identifier,
+				Extendable:        extendable,
+				IterationExponent: iterationExponent,
+				GroupIndex:        groupIdx,
+				GroupThreshold:    groupThreshold,
+				GroupCount:        len(groups),
+				MemberIndex:       memberIdx,
+				MemberThreshold:   group.MemberThreshold,
+				Value:             value,
+			}
+
+			mnemonic, err := se.EncodeShare(share)
+			if err != nil {
+				return nil, err
+			}
+			mnemonics[groupIdx] = append(mnemonics[groupIdx], mnemonic)
+		}
+	}
+
+	return mnemonics, nil
+}
+
+// CombineMnemonics recovers the master secret from a sufficient set of
+// SLIP-0039 mnemonics, decrypting it with passphrase.
+func (se *SLIP39Encoder) CombineMnemonics(mnemonics []string, passphrase []byte) ([]byte, error) {
+	if len(mnemonics) == 0 {
+		return nil, errors.New("no mnemonics provided")
+	}
+
+	shares := make([]SLIP39Share, len(mnemonics))
+	for i, mnemonic := range mnemonics {
+		share, err := se.DecodeShare(mnemonic)
+		if err != nil {
+			return nil, fmt.Errorf("mnemonic %d: %v", i, err)
+		}
+		shares[i] = share
+	}
+
+	first := shares[0]
+	groups := make(map[int][]SLIP39Share)
+	for i, share := range shares {
+		if share.Identifier != first.Identifier || share.Extendable != first.Extendable || share.IterationExponent != first.IterationExponent {
+			return nil, fmt.Errorf("mnemonic %d belongs to a different secret", i)
+		}
+		if share.GroupThreshold != first.GroupThreshold || share.GroupCount != first.GroupCount {
+			return nil, fmt.Errorf("mnemonic %d has mismatched group parameters", i)
+		}
+		if len(share.Value) != len(first.Value) {
+			return nil, fmt.Errorf("mnemonic %d has a different share length", i)
+		}
+		groups[share.GroupIndex] = append(groups[share.GroupIndex], share)
+	}
+
+	if len(groups) < first.GroupThreshold {
+		return nil, fmt.Errorf("insufficient groups: need %d, got %d", first.GroupThreshold, len(groups))
+	}
+
+	var groupIndices []int
+	var groupValues [][]byte
+
+	for groupIdx, members := range groups {
+		memberThreshold := members[0].MemberThreshold
+		indices := make([]int, 0, len(members))
+		values := make([][]byte, 0, len(members))
+		seen := make(map[int]bool)
+
+		for _, member := range members {
+			if member.MemberThreshold != memberThreshold {
+				return nil, fmt.Errorf("group %d has mismatched member thresholds", groupIdx)
+			}
+			if seen[member.MemberIndex] {
+				return nil, fmt.Errorf("group %d has duplicate member index %d", groupIdx, member.MemberIndex)
+			}
+			seen[member.MemberIndex] = true
+			indices = append(indices, member.MemberIndex)
+			values = append(values, member.Value)
+		}
+
+		if len(members) < memberThreshold {
+			// An incomplete group is only a problem if it is needed
+			continue
+		}
+
+		groupValue, err := slip39RecoverSecret(memberThreshold, indices[:memberThreshold], values[:memberThreshold])
+		if err != nil {
+			return nil, fmt.Errorf("group %d: %v", groupIdx, err)
+		}
+		groupIndices = append(groupIndices, groupIdx)
+		groupValues = append(groupValues, groupValue)
+	}
+
+	if len(groupIndices) < first.GroupThreshold {
+		return nil, fmt.Errorf("insufficient complete groups: need %d, got %d", first.GroupThreshold, len(groupIndices))
+	}
+
+	encrypted, err := slip39RecoverSecret(first.GroupThreshold, groupIndices[:first.GroupThreshold], groupValues[:first.GroupThreshold])
+	if err != nil {
+		return nil, err
+	}
+
+	return slip39Decrypt(encrypted, passphrase, first.IterationExponent, first.Identifier, first.Extendable), nil
+}
+
+// EncodeShare writes a share as a SLIP-0039 mnemonic with its RS1024
+// checksum.
+func (se *SLIP39Encoder) EncodeShare(share SLIP39Share) (string, error) {
+	if len(share.Value) < slip39MinStrengthBytes || len(share.Value)%2 != 0 {
+		return "", fmt.Errorf("share value must be an even number of bytes, at least %d", slip39MinStrengthBytes)
+	}
+	if share.Identifier >= 1<<slip39IDBits || share.IterationExponent < 0 || share.IterationExponent >= 1<<slip39IterationExpBits {
+		return "", errors.New("identifier or iteration exponent out of range")
+	}
+	for _, field := range []int{share.GroupIndex, share.GroupThreshold - 1, share.GroupCount - 1, share.MemberIndex, share.MemberThreshold - 1} {
+		if field < 0 || field >= slip39MaxShareCount {
+			return "", errors.New("group or member parameters out of range")
+		}
+	}
+
+	ext := 0
+	if share.Extendable {
+		ext = 1
+	}
+
+	// Identifier, extendable flag and iteration exponent fill the first two
+	// words; the group and member parameters fill the next two
+	idExp := int(share.Identifier)<<5 | ext<<4 | share.IterationExponent
+	params := share.GroupIndex<<16 | (share.GroupThreshold-1)<<12 | (share.GroupCount-1)<<8 | share.MemberIndex<<4 | (share.MemberThreshold - 1)
+
+	values := []int{idExp >> 10, idExp & 0x3ff, params >> 10, params & 0x3ff}
+
+	valueWords := (len(share.Value)*8 + slip39RadixBits - 1) / slip39RadixBits
+	valueInt := new(big.Int).SetBytes(share.Value)
+	for i := valueWords - 1; i >= 0; i-- {
+		word := new(big.Int).Rsh(valueInt, uint(i*slip39RadixBits))
+		values = append(values, int(word.Int64()&0x3ff))
+	}
+
+	values = append(values, slip39CreateChecksum(values, share.Extendable)...)
+
+	words := make([]string, len(values))
+	for i, index := range values {
+		words[i] = se.wordList[index]
+	}
+
+	return strings.Join(words, " "), nil
+}
+
+// DecodeShare parses a SLIP-0039 mnemonic and verifies its checksum.
+func (se *SLIP39Encoder) DecodeShare(mnemonic string) (SLIP39Share, error) {
+	words := strings.Fields(strings.ToLower(mnemonic))
+	if len(words) < slip39MinMnemonicLength {
+		return SLIP39Share{}, fmt.Errorf("mnemonic must be at least %d words", slip39MinMnemonicLength)
+	}
+
+	values := make([]int, len(words))
+	for i, word := range words {
+		index, exists := se.wordMap[word]
+		if !exists {
+			return SLIP39Share{}, fmt.Errorf("unknown word: %s", word)
+		}
+		values[i] = index
+	}
+
+	paddingBits := (slip39RadixBits * (len(values) - slip39MetadataWords - slip39ChecksumWords)) % 16
+	if paddingBits > 8 {
+		return SLIP39Share{}, errors.New("invalid mnemonic length")
+	}
+
+	idExp := values[0]<<10 | values[1]
+	extendable := (idExp>>4)&1 == 1
+
+	if !slip39VerifyChecksum(values, extendable) {
+		return SLIP39Share{}, errors.New("invalid mnemonic checksum")
+	}
+
+	params := values[2]<<10 | values[3]
+	share := SLIP39Share{
+		Identifier:        uint16(idExp >> 5),
+		Extendable:        extendable,
+		IterationExponent: idExp & 0xf,
+		GroupIndex:        params >> 16,
+		GroupThreshold:    (params>>12)&0xf + 1,
+		GroupCount:        (params>>8)&0xf + 1,
+		MemberIndex:       (params >> 4) & 0xf,
+		MemberThreshold:   params&0xf + 1,
+	}
+
+	if share.GroupThreshold > share.GroupCount {
+		return SLIP39Share{}, errors.New("group threshold exceeds group count")
+	}
+
+	valueInt := new(big.Int)
+	for _, index := range values[slip39MetadataWords : len(values)-slip39ChecksumWords] {
+		valueInt.Lsh(valueInt, slip39RadixBits)
+		valueInt.Or(valueInt, big.NewInt(int64(index)))
+	}
+
+	valueBits := slip39RadixBits*(len(values)-slip39MetadataWords-slip39ChecksumWords) - paddingBits
+	if valueInt.BitLen() > valueBits {
+		return SLIP39Share{}, errors.New("invalid mnemonic padding")
+	}
+	share.Value = valueInt.FillBytes(make([]byte, valueBits/8))
+
+	return share, nil
+}
+
+var slip39Generator = [10]uint32{
+	0xE0E040, 0x1C1C080, 0x3838100, 0x7070200, 0xE0E0009,
+	0x1C0C2412, 0x38086C24, 0x3090FC48, 0x21B1F890, 0x3F3F120,
+}
+
+func slip39Polymod(values []int) uint32 {
+	chk := uint32(1)
+	for _, v := range values {
+		b := chk >> 20
+		chk = (chk&0xFFFFF)<<10 ^ uint32(v)
+		for i := 0; i < 10; i++ {
+			if (b>>i)&1 == 1 {
+				chk ^= slip39Generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+func slip39CustomizationValues(extendable bool) []int {
+	customization := slip39Customization
+	if extendable {
+		customization = slip39CustomizationExt
+	}
+
+	values := make([]int, len(customization))
+	for i := range customization {
+		values[i] = int(customization[i])
+	}
+	return values
+}
+
+// slip39CreateChecksum computes the three RS1024 checksum words.
+func slip39CreateChecksum(data []int, extendable bool) []int {
+	values := append(slip39CustomizationValues(extendable), data...)
+	values = append(values, 0, 0, 0)
+	polymod := slip39Polymod(values) ^ 1
+
+	return []int{int(polymod>>20) & 0x3ff, int(polymod>>10) & 0x3ff, int(polymod) & 0x3ff}
+}
+
+func slip39VerifyChecksum(data []int, extendable bool) bool {
+	return slip39Polymod(append(slip39CustomizationValues(extendable), data...)) == 1
+}
+
+// slip39Salt is the Feistel salt prefix: the customization string and the
+// identifier, or nothing for extendable backups.
+func slip39Salt(identifier uint16, extendable bool) []byte {
+	if extendable {
+		return nil
+	}
+	return binary.BigEndian.AppendUint16([]byte(slip39Customization), identifier)
+}
+
+func slip39RoundFunction(round int, passphrase []byte, iterationExponent int, salt, r []byte) []byte {
+	password := append([]byte{byte(round)}, passphrase...)
+	iterations := (slip39BaseIterations << iterationExponent) / slip39RoundCount
+	return pbkdf2SHA256(password, append(append([]byte{}, salt...), r...), iterations, len(r))
+}
+
+// slip39Encrypt runs the four-round Feistel network that turns the master
+// secret into the encrypted master secret.
+func slip39Encrypt(masterSecret, passphrase []byte, iterationExponent int, identifier uint16, extendable bool) []byte {
+	half := len(masterSecret) / 2
+	l := append([]byte{}, masterSecret[:half]...)
+	r := append([]byte{}, masterSecret[half:]...)
+	salt := slip39Salt(identifier, extendable)
+
+	for round := 0; round < slip39RoundCount; round++ {
+		f := slip39RoundFunction(round, passphrase, iterationExponent, salt, r)
+		l, r = r, xorBytes(l, f)
+	}
+
+	return append(r, l...)
+}
+
+func slip39Decrypt(encrypted, passphrase []byte, iterationExponent int, identifier uint16, extendable bool) []byte {
+	half := len(encrypted) / 2
+	l := append([]byte{}, encrypted[:half]...)
+	r := append([]byte{}, encrypted[half:]...)
+	salt := slip39Salt(identifier, extendable)
+
+	for round := slip39RoundCount - 1; round >= 0; round-- {
+		f := slip39RoundFunction(round, passphrase, iterationExponent, salt, r)
+		l, r = r, xorBytes(l, f)
+	}
+
+	return append(r, l...)
+}
+
+func xorBytes(a, b []byte) []byte {
+	result := make([]byte, len(a))
+	for i := range a {
+		result[i] = a[i] ^ b[i]
+	}
+	return result
+}
+
+// pbkdf2SHA256 implements PBKDF2 (RFC 8018) with HMAC-SHA256.
+func pbkdf2SHA256(password, salt []byte, iterations, keyLen int) []byte {
+	prf := hmac.New(sha256.New, password)
+	result := make([]byte, 0, keyLen)
+
+	for block := uint32(1); len(result) < keyLen; block++ {
+		prf.Reset()
+		prf.Write(salt)
+		prf.Write(binary.BigEndian.AppendUint32(nil, block))
+		u := prf.Sum(nil)
+
+		t := append([]byte{}, u...)
+		for i := 1; i < iterations; i++ {
+			prf.Reset()
+			prf.Write(u)
+			u = prf.Sum(u[:0])
+			for j := range t {
+				t[j] ^= u[j]
+			}
+		}
+		result = append(result, t...)
+	}
+
+	return result[:keyLen]
+}
+
+// slip39SplitSecret shares secret over GF(256) as SLIP-0039 specifies: the
+// secret sits at x=255 and a digest share at x=254 lets recovery detect
+// wrong shares.
+func slip39SplitSecret(threshold, shareCount int, secret []byte) ([][]byte, error) {
+	if threshold < 1 || threshold > shareCount || shareCount > slip39MaxShareCount {
+		return nil, errors.New("invalid threshold or share count")
+	}
+
+	if threshold == 1 {
+		shares := make([][]byte, shareCount)
+		for i := range shares {
+			shares[i] = append([]byte{}, secret...)
+		}
+		return shares, nil
+	}
+
+	randomShareCount := threshold - 2
+	shares := make([][]byte, 0, shareCount)
+	indices := make([]int, 0, threshold)
+	values := make([][]byte, 0, threshold)
+
+	for i := 0; i < randomShareCount; i++ {
+		share := make([]byte, len(secret))
+		if _, err := rand.Read(share); err != nil {
+			return nil, fmt.Errorf("failed to generate random share: %v", err)
+		}
+		shares = append(shares, share)
+		indices = append(indices, i)
+		values = append(values, share)
+	}
+
+	randomPart := make([]byte, len(secret)-slip39DigestLength)
+	if _, err := rand.Read(randomPart); err != nil {
+		return nil, fmt.Errorf("failed to generate digest share: %v", err)
+	}
+	digestShare := append(slip39Digest(randomPart, secret), randomPart...)
+
+	indices = append(indices, slip39DigestIndex, slip39SecretIndex)
+	values = append(values, digestShare, secret)
+
+	for i := randomShareCount; i < shareCount; i++ {
+		shares = append(shares, gf256Interpolate(indices, values, i))
+	}
+
+	return shares, nil
+}
+
+// slip39RecoverSecret interpolates the secret from threshold shares and
+// checks it against the digest share.
+func slip39RecoverSecret(threshold int, indices []int, values [][]byte) ([]byte, error) {
+	if threshold == 1 {
+		return append([]byte{}, values[0]...), nil
+	}
+
+	secret := gf256Interpolate(indices, values, slip39SecretIndex)
+	digestShare := gf256Interpolate(indices, values, slip39DigestIndex)
+
+	digest := digestShare[:slip39DigestLength]
+	randomPart := digestShare[slip39DigestLength:]
+	if !hmac.Equal(digest, slip39Digest(randomPart, secret)) {
+		return nil, errors.New("invalid digest of the shared secret")
+	}
+
+	return secret, nil
+}
+
+func slip39Digest(randomPart, secret []byte) []byte {
+	mac := hmac.New(sha256.New, randomPart)
+	mac.Write(secret)
+	return mac.Sum(nil)[:slip39DigestLength]
+}
+
+// GF(256) arithmetic with the Rijndael polynomial x^8 + x^4 + x^3 + x + 1.
+var gf256Exp, gf256Log = gf256Tables()
+
+func gf256Tables() ([255]byte, [256]byte) {
+	var exp [255]byte
+	var log [256]byte
+
+	poly := 1
+	for i := 0; i < 255; i++ {
+		exp[i] = byte(poly)
+		log[poly] = byte(i)
+
+		// Multiply by the generator x + 1
+		poly = (poly << 1) ^ poly
+		if poly&0x100 != 0 {
+			poly ^= 0x11B
+		}
+	}
+
+	return exp, log
+}
+
+// gf256Interpolate evaluates at x the polynomial through the points
+// (indices[i], values[i]), byte by byte.
+func gf256Interpolate(indices []int, values [][]byte, x int) []byte {
+	for i, index := range indices {
+		if index == x {
+			return append([]byte{}, values[i]...)
+		}
+	}
+
+	// Log of the product of (x - x_i); subtraction is XOR in GF(256)
+	logProd := 0
+	for _, index := range indices {
+		logProd += int(gf256Log[index^x])
+	}
+
+	result := make([]byte, len(values[0]))
+	for i, index := range indices {
+		// Basis polynomial L_i(x) = Π_{j≠i} (x - x_j) / (x_i - x_j)
+		logBasis := logProd - int(gf256Log[index^x])
+		for _, other := range indices {
+			if other != index {
+				logBasis -= int(gf256Log[index^other])
+			}
+		}
+		logBasis = ((logBasis % 255) + 255) % 255
+
+		for k, b := range values[i] {
+			if b != 0 {
+				result[k] ^= gf256Exp[(int(gf256Log[b])+logBasis)%255]
+			}
+		}
+	}
+
+	return result
+}
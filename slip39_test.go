@@ -0,0 +1,154 @@
+package pvss
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+	"strings"
+	"testing"
+)
+
+// TestSLIP39WordsList tests the word list size, ordering and unique prefixes
+func TestSLIP39WordsList(t *testing.T) {
+	words := SLIP39Words()
+
+	if len(words) != 1024 {
+		t.Fatalf("expected 1024 words, got %d", len(words))
+	}
+
+	if !sort.StringsAreSorted(words) {
+		t.Error("word list is not sorted")
+	}
+
+	prefixes := make(map[string]string)
+	for _, word := range words {
+		prefix := word[:4]
+		if other, exists := prefixes[prefix]; exists {
+			t.Errorf("words %q and %q share the prefix %q", other, word, prefix)
+		}
+		prefixes[prefix] = word
+	}
+}
+
+// TestSLIP39_Vector tests recovery of an official SLIP-0039 test vector
+func TestSLIP39_Vector(t *testing.T) {
+	encoder := NewSLIP39Encoder()
+
+	mnemonic := "duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision keyboard"
+
+	secret, err := encoder.CombineMnemonics([]string{mnemonic}, []byte("TREZOR"))
+	if err != nil {
+		t.Fatalf("CombineMnemonics failed: %v", err)
+	}
+
+	expected := "bb54aac4b89dc868ba37d9cc21b2cece"
+	if hex.EncodeToString(secret) != expected {
+		t.Errorf("expected %s, got %x", expected, secret)
+	}
+
+	// Re-encoding the parsed share gives back the same mnemonic
+	share, err := encoder.DecodeShare(mnemonic)
+	if err != nil {
+		t.Fatalf("DecodeShare failed: %v", err)
+	}
+	reencoded, err := encoder.EncodeShare(share)
+	if err != nil {
+		t.Fatalf("EncodeShare failed: %v", err)
+	}
+	if reencoded != mnemonic {
+		t.Errorf("re-encoded mnemonic differs:\n got %s\nwant %s", reencoded, mnemonic)
+	}
+}
+
+// TestSLIP39_InvalidChecksum tests that a changed word is detected
+func TestSLIP39_InvalidChecksum(t *testing.T) {
+	encoder := NewSLIP39Encoder()
+
+	// Official vector 2: last word changed from "keyboard" to "kidney"
+	mnemonic := "duckling enlarge academic academic agency result length solution fridge kidney coal piece deal husband erode duke ajar critical decision kidney"
+
+	if _, err := encoder.DecodeShare(mnemonic); err == nil {
+		t.Error("expected checksum error")
+	}
+}
+
+// TestSLIP39_GroupRoundTrip tests a two-level sharing with a spare group
+func TestSLIP39_GroupRoundTrip(t *testing.T) {
+	encoder := NewSLIP39Encoder()
+
+	secret := []byte("0123456789abcdef0123456789abcdef")
+	groups := []SLIP39Group{
+		{MemberThreshold: 1, MemberCount: 1},
+		{MemberThreshold: 2, MemberCount: 3},
+		{MemberThreshold: 3, MemberCount: 5},
+	}
+
+	for _, extendable := range []bool{false, true} {
+		mnemonics, err := encoder.GenerateMnemonics(2, groups, secret, []byte("pass"), 0, extendable)
+		if err != nil {
+			t.Fatalf("GenerateMnemonics failed: %v", err)
+		}
+
+		if len(mnemonics) != len(groups) {
+			t.Fatalf("expected %d groups, got %d", len(groups), len(mnemonics))
+		}
+		for i, group := range groups {
+			if len(mnemonics[i]) != group.MemberCount {
+				t.Fatalf("group %d: expected %d mnemonics, got %d", i, group.MemberCount, len(mnemonics[i]))
+			}
+		}
+
+		// Group 1 (two of three) plus group 2 (three of five)
+		selected := []string{mnemonics[1][0], mnemonics[1][2], mnemonics[2][1], mnemonics[2][3], mnemonics[2][4]}
+		recovered, err := encoder.CombineMnemonics(selected, []byte("pass"))
+		if err != nil {
+			t.Fatalf("CombineMnemonics failed: %v", err)
+		}
+		if !bytes.Equal(recovered, secret) {
+			t.Errorf("extendable=%v: expected %x, got %x", extendable, secret, recovered)
+		}
+
+		// The single-member group with an incomplete group is not enough
+		if _, err := encoder.CombineMnemonics([]string{mnemonics[0][0], mnemonics[1][0]}, []byte("pass")); err == nil {
+			t.Error("expected error with only one complete group")
+		}
+
+		// A wrong passphrase yields a different secret rather than an error
+		wrong, err := encoder.CombineMnemonics(selected, []byte("other"))
+		if err != nil {
+			t.Fatalf("CombineMnemonics failed: %v", err)
+		}
+		if bytes.Equal(wrong, secret) {
+			t.Error("wrong passphrase recovered the secret")
+		}
+	}
+}
+
+// TestSLIP39_WrongShareDetected tests that mixing shares of different secrets fails
+func TestSLIP39_WrongShareDetected(t *testing.T) {
+	encoder := NewSLIP39Encoder()
+
+	groups := []SLIP39Group{{MemberThreshold: 2, MemberCount: 3}}
+	first, err := encoder.GenerateMnemonics(1, groups, bytes.Repeat([]byte{1}, 16), nil, 0, false)
+	if err != nil {
+		t.Fatalf("GenerateMnemonics failed: %v", err)
+	}
+	second, err := encoder.GenerateMnemonics(1, groups, bytes.Repeat([]byte{2}, 16), nil, 0, false)
+	if err != nil {
+		t.Fatalf("GenerateMnemonics failed: %v", err)
+	}
+
+	// Forge a share carrying the first identifier but a foreign value
+	share, _ := encoder.DecodeShare(first[0][1])
+	foreign, _ := encoder.DecodeShare(second[0][1])
+	share.Value = foreign.Value
+	forged, err := encoder.EncodeShare(share)
+	if err != nil {
+		t.Fatalf("EncodeShare failed: %v", err)
+	}
+
+	_, err = encoder.CombineMnemonics([]string{first[0][0], forged}, nil)
+	if err == nil || !strings.Contains(err.Error(), "digest") {
+		t.Errorf("expected digest error, got %v", err)
+	}
+}
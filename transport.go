@@ -0,0 +1,117 @@
+package pvss
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// TransportMessage is a message delivered to a participant of a
+// distributed key generation.
+type TransportMessage struct {
+	From      int  // Participant ID of the sender, as authenticated by the transport
+	Broadcast bool // Whether the message was broadcast rather than sent privately
+	Payload   []byte
+}
+
+// Transport carries one participant's messages in a distributed key
+// generation. Implementations must authenticate senders, keep private
+// messages confidential, and deliver each broadcast unchanged to every
+// participant.
+type Transport interface {
+	// Broadcast sends payload to every other participant.
+	Broadcast(ctx context.Context, payload []byte) error
+	// Send sends payload privately to the participant with the given ID.
+	Send(ctx context.Context, to int, payload []byte) error
+	// Receive blocks until a message arrives or ctx is done.
+	Receive(ctx context.Context) (TransportMessage, error)
+}
+
+// MemoryNetwork connects participants 1 to n in the same process, for tests
+// and simulations. Messages are queued without limit and never lost.
+type MemoryNetwork struct {
+	inboxes map[int]*memoryInbox
+}
+
+type memoryInbox struct {
+	mu    sync.Mutex
+	queue []TransportMessage
+	ready chan struct{} // Signalled when the queue becomes non-empty
+}
+
+// NewMemoryNetwork creates a network of n participants with IDs 1 to n.
+func NewMemoryNetwork(n int) *MemoryNetwork {
+	network := &MemoryNetwork{inboxes: make(map[int]*memoryInbox, n)}
+	for id := 1; id <= n; id++ {
+		network.inboxes[id] = &memoryInbox{ready: make(chan struct{}, 1)}
+	}
+	return network
+}
+
+// Transport returns the endpoint of the participant with the given ID.
+func (network *MemoryNetwork) Transport(id int) Transport {
+	if network.inboxes[id] == nil {
+		panic(fmt.Sprintf("pvss: no participant %d in memory network", id))
+	}
+	return &memoryTransport{network: network, id: id}
+}
+
+type memoryTransport struct {
+	network *MemoryNetwork
+	id      int
+}
+
+func (t *memoryTransport) Broadcast(ctx context.Context, payload []byte) error {
+	for id, inbox := range t.network.inboxes {
+		if id != t.id {
+			inbox.push(TransportMessage{From: t.id, Broadcast: true, Payload: append([]byte{}, payload...)})
+		}
+	}
+	return ctx.Err()
+}
+
+func (t *memoryTransport) Send(ctx context.Context, to int, payload []byte) error {
+	inbox := t.network.inboxes[to]
+	if inbox == nil {
+		return fmt.Errorf("no participant %d in memory network", to)
+	}
+	inbox.push(TransportMessage{From: t.id, Payload: append([]byte{}, payload...)})
+	return ctx.Err()
+}
+
+func (t *memoryTransport) Receive(ctx context.Context) (TransportMessage, error) {
+	inbox := t.network.inboxes[t.id]
+	for {
+		if msg, ok := inbox.pop(); ok {
+			return msg, nil
+		}
+		select {
+		case <-inbox.ready:
+		case <-ctx.Done():
+			return TransportMessage{}, ctx.Err()
+		}
+	}
+}
+
+func (inbox *memoryInbox) push(msg TransportMessage) {
+	inbox.mu.Lock()
+	inbox.queue = append(inbox.queue, msg)
+	inbox.mu.Unlock()
+
+	select {
+	case inbox.ready <- struct{}{}:
+	default:
+	}
+}
+
+func (inbox *memoryInbox) pop() (TransportMessage, bool) {
+	inbox.mu.Lock()
+	defer inbox.mu.Unlock()
+
+	if len(inbox.queue) == 0 {
+		return TransportMessage{}, false
+	}
+	msg := inbox.queue[0]
+	inbox.queue = inbox.queue[1:]
+	return msg, true
+}